@@ -0,0 +1,234 @@
+package go_openrpc_reflect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// MethodInvoker is implemented by reflectors which are able to call the Go methods they document,
+// given values for the document's params. Tooling like openrpcfuzz uses it to exercise receivers.
+//
+// Reflectors whose params don't map one-to-one onto Go arguments, eg. because GetMethodParams
+// is overridden, should override these too.
+type MethodInvoker interface {
+	// GetMethodParamTypes returns the Go types of the method's document params, in order.
+	GetMethodParamTypes(r reflect.Value, m reflect.Method) ([]reflect.Type, error)
+	// InvokeMethod calls the method with values for its document params,
+	// returning its result (nil if it has none) and the error it returned.
+	InvokeMethod(r reflect.Value, m reflect.Method, params []reflect.Value) (result interface{}, err error)
+}
+
+var errNotInvoker = errors.New("reflector does not implement MethodInvoker")
+
+// MethodBinding ties a method of a discovered document to the receiver method implementing it.
+type MethodBinding struct {
+	Method   meta_schema.MethodObject
+	Receiver reflect.Value
+	Func     reflect.Method
+
+	reflector ReceiverRegisterer
+}
+
+// Name returns the document name of the method.
+func (b MethodBinding) Name() string {
+	if b.Method.Name == nil {
+		return ""
+	}
+	return string(*b.Method.Name)
+}
+
+// ParamTypes returns the Go types of the method's params.
+// It fails if the document's reflector doesn't implement MethodInvoker.
+func (b MethodBinding) ParamTypes() ([]reflect.Type, error) {
+	inv, ok := b.reflector.(MethodInvoker)
+	if !ok {
+		return nil, errNotInvoker
+	}
+	return inv.GetMethodParamTypes(b.Receiver, b.Func)
+}
+
+// Call calls the method with values for its params, returning its result and the error it returned.
+// It fails if the document's reflector doesn't implement MethodInvoker.
+func (b MethodBinding) Call(params []reflect.Value) (interface{}, error) {
+	inv, ok := b.reflector.(MethodInvoker)
+	if !ok {
+		return nil, errNotInvoker
+	}
+	return inv.InvokeMethod(b.Receiver, b.Func, params)
+}
+
+// Bindings discovers the document, returning its methods together with
+// the receivers and Go methods which implement them, ordered like the document's methods.
+// It fails if more than one receiver method has the same name.
+// Methods documented per namespace rather than implemented by a receiver method,
+// eg. go-ethereum's subscribe and unsubscribe methods, are left out.
+func (d *Document) Bindings() ([]MethodBinding, error) {
+	return d.BindingsContext(context.Background())
+}
+
+// BindingsContext is Bindings for the document discovered for the caller of the context; see DiscoverContext.
+// Only the receivers documented for the context's transport are bound, so that receivers registered
+// on other transports don't conflict with them, and a receiver registered more than once is bound once.
+func (d *Document) BindingsContext(ctx context.Context) ([]MethodBinding, error) {
+	doc, err := d.DiscoverContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Methods == nil || d.reflector == nil {
+		return []MethodBinding{}, nil
+	}
+	transport, _ := TransportFromContext(ctx)
+
+	byName := map[string]MethodBinding{}
+	for i, rec := range d.receivers {
+		if !onTransport(d.receiverTransports[i], transport) {
+			continue
+		}
+		if _, served, err := d.receiverServers(i, transport); err != nil {
+			return nil, fmt.Errorf("listener error: %w", err)
+		} else if !served {
+			continue
+		}
+		rval := reflect.ValueOf(rec)
+		ty := rval.Type()
+		for j := 0; j < ty.NumMethod(); j++ {
+			method := ty.Method(j)
+			if !d.reflector.IsMethodEligible(method) {
+				continue
+			}
+			fdecl, err := getAstFuncDecl(rval, method)
+			if err != nil {
+				if err == errAutogenerated {
+					continue
+				}
+				return nil, fmt.Errorf("getAstFuncDecl error: %w, receiver: %v", err, ty.String())
+			}
+			name, err := d.reflector.GetMethodName(d.receiverNames[i], rval, method, fdecl)
+			if err != nil {
+				return nil, err
+			}
+			if prev, ok := byName[name]; ok {
+				if sameReceiver(prev.Receiver, rval) && prev.Func.Name == method.Name {
+					continue
+				}
+				return nil, fmt.Errorf("method %s: implemented by both %v.%s and %v.%s",
+					name, prev.Receiver.Type(), prev.Func.Name, ty, method.Name)
+			}
+			byName[name] = MethodBinding{Receiver: rval, Func: method, reflector: d.reflector}
+		}
+	}
+
+	generated, err := d.namespaceMethodNames()
+	if err != nil {
+		return nil, err
+	}
+
+	out := []MethodBinding{}
+	for _, m := range *doc.Methods {
+		b, ok := byName[string(*m.Name)]
+		if !ok && generated[string(*m.Name)] {
+			continue
+		}
+		if !ok {
+			return nil, fmt.Errorf("method %s: no receiver method found", *m.Name)
+		}
+		b.Method = m
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+// sameReceiver tells whether two receivers are the same pointer.
+func sameReceiver(a, b reflect.Value) bool {
+	return a.Type() == b.Type() && a.Kind() == reflect.Ptr && a.Pointer() == b.Pointer()
+}
+
+// namespaceMethodNames returns the names of the methods the reflector documents per namespace
+// for the document's receivers, if it documents any.
+func (d *Document) namespaceMethodNames() (map[string]bool, error) {
+	out := map[string]bool{}
+	registerer, ok := d.reflector.(namespaceMethodsRegisterer)
+	if !ok {
+		return out, nil
+	}
+	all := make([]int, len(d.receivers))
+	for i := range all {
+		all[i] = i
+	}
+	namespaces, byNamespace := d.receiverNamespaces(registerer, all)
+	for _, ns := range namespaces {
+		ms, err := registerer.namespaceMethods(ns, d.receiversAt(byNamespace[ns]), nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range ms {
+			out[string(*m.Name)] = true
+		}
+	}
+	return out, nil
+}
+
+// GetMethodParamTypes returns the args type, the only param of net/rpc methods,
+// or the types of its fields if FlattenArgs is set.
+func (c *StandardReflectorT) GetMethodParamTypes(r reflect.Value, m reflect.Method) ([]reflect.Type, error) {
+	if c.FlattenArgs && isFlattenableArg(m.Type.In(1)) {
+		return flattenedParamTypes(m, m.Type.In(1)), nil
+	}
+	return []reflect.Type{m.Type.In(1)}, nil
+}
+
+// InvokeMethod calls a net/rpc method, returning the value of its reply.
+func (c *StandardReflectorT) InvokeMethod(r reflect.Value, m reflect.Method, params []reflect.Value) (interface{}, error) {
+	arg := params[0]
+	if c.FlattenArgs && isFlattenableArg(m.Type.In(1)) {
+		arg = assembleArg(m, m.Type.In(1), params)
+	}
+	reply := reflect.New(m.Type.In(2).Elem())
+	out := m.Func.Call([]reflect.Value{r, arg, reply})
+	if err, _ := out[0].Interface().(error); err != nil {
+		return nil, err
+	}
+	return reply.Elem().Interface(), nil
+}
+
+// GetMethodParamTypes returns the method's argument types, less a leading context.Context.
+func (e *EthereumReflectorT) GetMethodParamTypes(r reflect.Value, m reflect.Method) ([]reflect.Type, error) {
+	out := []reflect.Type{}
+	for i := 1; i < m.Type.NumIn(); i++ {
+		if i == 1 && m.Type.In(i) == contextType {
+			continue
+		}
+		out = append(out, m.Type.In(i))
+	}
+	return out, nil
+}
+
+// InvokeMethod calls a go-ethereum/rpc method, passing a background context if it takes one.
+func (e *EthereumReflectorT) InvokeMethod(r reflect.Value, m reflect.Method, params []reflect.Value) (interface{}, error) {
+	in := []reflect.Value{r}
+	if m.Type.NumIn() > 1 && m.Type.In(1) == contextType {
+		in = append(in, reflect.ValueOf(context.Background()))
+	}
+	in = append(in, params...)
+
+	call := m.Func.Call
+	if m.Type.IsVariadic() {
+		// The variadic param's value is the slice of its arguments.
+		call = m.Func.CallSlice
+	}
+	var result interface{}
+	for _, v := range call(in) {
+		if v.Type() == errType {
+			if err, _ := v.Interface().(error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		result = v.Interface()
+	}
+	return result, nil
+}
@@ -0,0 +1,132 @@
+package go_openrpc_reflect
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakearithmetic"
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakeethereum"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument_Bindings(t *testing.T) {
+	t.Run("standard", func(t *testing.T) {
+		calculator := &fakearithmetic.Calculator{}
+		calculator.Reset()
+		d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(StandardReflector)
+		d.RegisterReceiver(&fakearithmetic.CalculatorRPC{Calculator: calculator})
+
+		bindings, err := d.Bindings()
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		assert.Len(t, bindings, 5)
+		b := bindings[0]
+		assert.Equal(t, "CalculatorRPC.Add", b.Name())
+		assert.Equal(t, "Add", b.Func.Name)
+
+		types, err := b.ParamTypes()
+		assert.NoError(t, err)
+		assert.Equal(t, []reflect.Type{reflect.TypeOf(fakearithmetic.AddArg{})}, types)
+
+		result, err := b.Call([]reflect.Value{reflect.ValueOf(fakearithmetic.AddArg{A: 1, B: 2})})
+		assert.NoError(t, err)
+		assert.Equal(t, fakearithmetic.AddReply(0), result)
+	})
+
+	t.Run("ethereum", func(t *testing.T) {
+		calculator := &fakearithmetic.Calculator{}
+		calculator.Reset()
+		d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(EthereumReflector)
+		d.RegisterReceiver(calculator)
+
+		bindings, err := d.Bindings()
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		byName := map[string]MethodBinding{}
+		for _, b := range bindings {
+			byName[b.Name()] = b
+		}
+
+		types, err := byName["calculator_add"].ParamTypes()
+		assert.NoError(t, err)
+		assert.Equal(t, []reflect.Type{reflect.TypeOf(0), reflect.TypeOf(0)}, types)
+
+		result, err := byName["calculator_add"].Call([]reflect.Value{reflect.ValueOf(1), reflect.ValueOf(2)})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result)
+
+		_, err = byName["calculator_div"].Call([]reflect.Value{reflect.ValueOf(1), reflect.ValueOf(0)})
+		assert.Error(t, err)
+
+		result, err = byName["calculator_reset"].Call(nil)
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("subscriptions", func(t *testing.T) {
+		d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(EthereumReflector)
+		d.RegisterReceiverName("eth", &fakeethereum.Chain{})
+		d.RegisterReceiverName("eth", &fakeethereum.Pool{})
+
+		bindings, err := d.Bindings()
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		names := []string{}
+		for _, b := range bindings {
+			names = append(names, b.Name())
+		}
+		assert.Equal(t, []string{"eth_headerByNumber", "eth_pendingCount"}, names)
+	})
+
+	t.Run("duplicate names", func(t *testing.T) {
+		d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(EthereumReflector)
+		d.RegisterReceiver(&fakearithmetic.Calculator{})
+		d.RegisterReceiver(&fakearithmetic.Calculator{})
+
+		_, err := d.Bindings()
+		assert.Error(t, err)
+	})
+
+	t.Run("transports", func(t *testing.T) {
+		// The same receiver served on two transports is bound once per transport's document.
+		calculator := &fakearithmetic.Calculator{}
+		d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(EthereumReflector)
+		d.RegisterReceiverTransports("calculator", calculator, "http")
+		d.RegisterReceiverTransports("calculator", calculator, "http", "ipc")
+		d.RegisterReceiverTransports("calculator", &fakearithmetic.Calculator{}, "ws")
+
+		for _, transport := range []string{"http", "ipc", "ws"} {
+			bindings, err := d.BindingsContext(ContextWithTransport(context.Background(), transport))
+			if !assert.NoError(t, err, transport) || !assert.NotEmpty(t, bindings, transport) {
+				continue
+			}
+			for _, b := range bindings {
+				assert.Equal(t, transport == "ws", b.Receiver.Pointer() != reflect.ValueOf(calculator).Pointer(), b.Name())
+			}
+		}
+
+		// Without a transport, only receivers registered against none are documented.
+		bindings, err := d.Bindings()
+		assert.NoError(t, err)
+		assert.Empty(t, bindings)
+	})
+
+	t.Run("not invoker", func(t *testing.T) {
+		reflector := struct{ ReceiverRegisterer }{StandardReflector}
+		d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(reflector)
+		d.RegisterReceiver(&fakearithmetic.CalculatorRPC{Calculator: &fakearithmetic.Calculator{}})
+
+		bindings, err := d.Bindings()
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		_, err = bindings[0].ParamTypes()
+		assert.Equal(t, errNotInvoker, err)
+		_, err = bindings[0].Call([]reflect.Value{reflect.ValueOf(fakearithmetic.AddArg{A: 1, B: 2})})
+		assert.Equal(t, errNotInvoker, err)
+	})
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/etclabscore/go-openrpc-reflect/openrpcdiff"
+)
+
+// errBreaking is returned by the diff command when breaking changes were found,
+// so that the command exits with exitBreaking, rather than 1 as on other errors.
+var errBreaking = errors.New("breaking changes found")
+
+// exitBreaking is the exit code of the diff command when breaking changes were found.
+const exitBreaking = 3
+
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	markdown := fs.Bool("markdown", false, "write a Markdown changelog")
+	out := fs.String("o", "", "output file (default stdout)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: openrpc-reflect diff [flags] <old.json> <new.json>\n\n")
+		fmt.Fprintf(fs.Output(), "Exits %d if any of the changes are breaking, and 1 on other errors.\n\n", exitBreaking)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return errors.New("expected two document arguments")
+	}
+
+	old, err := readDocument(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	new, err := readDocument(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	changes := openrpcdiff.Compare(old, new)
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if *markdown {
+		err = openrpcdiff.Markdown(w, changes)
+	} else {
+		for _, c := range changes {
+			if _, err = fmt.Fprintln(w, c); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if openrpcdiff.HasBreaking(changes) {
+		return errBreaking
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/etclabscore/go-openrpc-reflect/openrpcgen"
+)
+
+func runGen(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ExitOnError)
+	style := fs.String("style", "standard", "method signature style: standard (net/rpc) or ethereum (go-ethereum/rpc)")
+	pkg := fs.String("package", "api", "name of the generated package")
+	out := fs.String("o", "", "output file (default stdout)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: openrpc-reflect gen [flags] <openrpc.json>\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected one document argument")
+	}
+
+	cfg := openrpcgen.Config{Package: *pkg}
+	switch *style {
+	case "standard":
+		cfg.Style = openrpcgen.StyleStandard
+	case "ethereum":
+		cfg.Style = openrpcgen.StyleEthereum
+	default:
+		return fmt.Errorf("unknown style: %s", *style)
+	}
+
+	doc, err := readDocument(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	src, err := openrpcgen.Generate(doc, cfg)
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(*out, src, 0644)
+}
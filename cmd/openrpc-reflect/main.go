@@ -0,0 +1,83 @@
+// Command openrpc-reflect provides tooling around OpenRPC documents
+// built with, or intended for, go-openrpc-reflect.
+//
+// Usage:
+//
+//	openrpc-reflect <command> [arguments]
+//
+// The commands are:
+//
+//	gen    generate Go service interfaces from an OpenRPC document
+//	diff   report changes between two OpenRPC documents, exiting 3 if any are breaking
+//	mock   serve a mock JSON-RPC API from an OpenRPC document
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"gen", "generate Go service interfaces from an OpenRPC document", runGen},
+	{"diff", "report changes between two OpenRPC documents, exiting 3 if any are breaking", runDiff},
+	{"mock", "serve a mock JSON-RPC API from an OpenRPC document", runMock},
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: openrpc-reflect <command> [arguments]\n\nThe commands are:\n\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "\t%-8s %s\n", c.name, c.usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	for _, c := range commands {
+		if c.name != os.Args[1] {
+			continue
+		}
+		if err := c.run(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "openrpc-reflect %s: %v\n", c.name, err)
+			if errors.Is(err, errBreaking) {
+				os.Exit(exitBreaking)
+			}
+			os.Exit(1)
+		}
+		return
+	}
+	usage()
+	os.Exit(2)
+}
+
+// readDocument reads an OpenRPC document from a file, or from stdin if path is "-".
+func readDocument(path string) (*meta_schema.OpenrpcDocument, error) {
+	var b []byte
+	var err error
+	if path == "-" {
+		b, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		b, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	doc := &meta_schema.OpenrpcDocument{}
+	if err := json.Unmarshal(b, doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return doc, nil
+}
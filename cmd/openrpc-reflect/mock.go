@@ -0,0 +1,37 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/etclabscore/go-openrpc-reflect/openrpcmock"
+)
+
+func runMock(args []string) error {
+	fs := flag.NewFlagSet("mock", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8545", "address to serve on")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: openrpc-reflect mock [flags] <openrpc.json>\n\n")
+		fmt.Fprintf(fs.Output(), "Serves a mock JSON-RPC 2.0 API over HTTP from the document.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected one document argument")
+	}
+
+	doc, err := readDocument(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	srv, err := openrpcmock.New(doc)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "serving mock of %s on http://%s\n", fs.Arg(0), *addr)
+	return http.ListenAndServe(*addr, srv)
+}
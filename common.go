@@ -54,7 +54,8 @@ func init() {
 	}
 }
 
-func receiverMethods(methodHandler MethodRegisterer, name string, receiver interface{}) (object []meta_schema.MethodObject, err error) {
+// receiverMethods builds the methods of the receiver, collecting their extensions in exts if it isn't nil.
+func receiverMethods(methodHandler MethodRegisterer, name string, receiver interface{}, exts methodExtensions) (object []meta_schema.MethodObject, err error) {
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("receiverMethods error: %w", err)
@@ -127,6 +128,11 @@ func receiverMethods(methodHandler MethodRegisterer, name string, receiver inter
 		if err != nil {
 			return nil, err
 		}
+		// Notifications have no result; reflectors build theirs as a zero content descriptor.
+		var result *meta_schema.MethodObjectResult
+		if resultCD.Name != nil || resultCD.Schema != nil {
+			result = &meta_schema.MethodObjectResult{ContentDescriptorObject: &resultCD}
+		}
 
 		methodErrors, err := methodHandler.GetMethodErrors(rval, method, fdecl)
 		if err != nil {
@@ -153,6 +159,11 @@ func receiverMethods(methodHandler MethodRegisterer, name string, receiver inter
 			return nil, err
 		}
 
+		servers, err := methodHandler.GetMethodServers(rval, method, fdecl)
+		if err != nil {
+			return nil, err
+		}
+
 		me := meta_schema.MethodObject{
 			Name:           (*meta_schema.MethodObjectName)(&name),
 			Description:    (*meta_schema.MethodObjectDescription)(&description),
@@ -160,18 +171,62 @@ func receiverMethods(methodHandler MethodRegisterer, name string, receiver inter
 			Tags:           tags,
 			ParamStructure: (*meta_schema.MethodObjectParamStructure)(&paramsStructure),
 			Params:         (*meta_schema.MethodObjectParams)(&params),
-			Result:         &meta_schema.MethodObjectResult{ContentDescriptorObject: &resultCD},
+			Result:         result,
 			Errors:         methodErrors,
 			Links:          links,
 			Examples:       examples,
 			Deprecated:     (*meta_schema.MethodObjectDeprecated)(&deprecated),
 			ExternalDocs:   exDocs,
+			Servers:        servers,
 		}
 		methods = append(methods, me)
+
+		if exts != nil {
+			ext, err := methodObjectExtensions(methodHandler, rval, method, fdecl)
+			if err != nil {
+				return nil, err
+			}
+			if len(ext) > 0 {
+				exts[name] = ext
+			}
+		}
 	}
 	return methods, nil
 }
 
+// descriptorField is the AST field and type which a content descriptor documents.
+type descriptorField struct {
+	field *ast.Field
+	ty    reflect.Type
+}
+
+// contentDescriptorFielder is implemented by reflectors which can tell the fields and types
+// documented by a method's params and result, as built by their GetMethodParams and GetMethodResult.
+type contentDescriptorFielder interface {
+	methodParamFields(m reflect.Method, funcDecl *ast.FuncDecl) []descriptorField
+	// methodResultField returns false if the result doesn't document a field.
+	methodResultField(m reflect.Method, funcDecl *ast.FuncDecl) (descriptorField, bool)
+	// overridesDescriptors tells whether GetMethodParams and GetMethodResult are overridden,
+	// and so don't document the fields.
+	overridesDescriptors() (params, result bool)
+}
+
+func (c *ReceiverReflectorT) overridesDescriptors() (params, result bool) {
+	return c.FnGetMethodParams != nil, c.FnGetMethodResult != nil
+}
+
+func buildContentDescriptorObjects(registerer ContentDescriptorRegisterer, r reflect.Value, m reflect.Method, fields []descriptorField) ([]meta_schema.ContentDescriptorObject, error) {
+	out := []meta_schema.ContentDescriptorObject{}
+	for _, f := range fields {
+		cd, err := buildContentDescriptorObject(registerer, r, m, f.field, f.ty)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, cd)
+	}
+	return out, nil
+}
+
 func buildContentDescriptorObject(registerer ContentDescriptorRegisterer, r reflect.Value, m reflect.Method, field *ast.Field, ty reflect.Type) (cd meta_schema.ContentDescriptorObject, err error) {
 	defer func() {
 		if err != nil {
@@ -255,20 +310,66 @@ func buildContentDescriptorObject(registerer ContentDescriptorRegisterer, r refl
 }
 
 func buildJSONSchemaObject(registerer SchemaRegisterer, r reflect.Value, m reflect.Method, field *ast.Field, ty reflect.Type) (schema meta_schema.JSONSchema, err error) {
+	// Types documenting their own schemas aren't reflected.
+	if provider, ok := schemaProviderOf(ty); ok {
+		schema = provider.OpenRPCSchema()
+		examples, err := registerer.SchemaExamples(ty)
+		if err != nil {
+			return schema, err
+		}
+		if examples != nil && schema.JSONSchemaObject != nil {
+			schema.JSONSchemaObject.Examples = examples
+		}
+		return schema, nil
+	}
+
+	issues := newSchemaIssues(registerer, r, m, field)
+
 	if !jsonschemaPkgSupport(ty) {
+		issues.warn(ty, "type unsupported by JSON Schema, documented as "+unsupportedTypeSchema.Title)
+		if err := issues.err(); err != nil {
+			return schema, err
+		}
 		err = json.Unmarshal([]byte(`{"type": "object", "title": "typeUnsupportedByJSONSchema"}`), &schema)
 		return
 	}
 
+	provided := &providedSchemas{}
+	var enums SchemaTypeMap
+	if e, ok := registerer.(enumsRegisterer); ok && e.schemaEnums() {
+		enums = enumSchemaTypeMap(m)
+	}
 	rflctr := jsonschema.Reflector{
 		AllowAdditionalProperties:  false,
 		RequiredFromJSONSchemaTags: true,
 		ExpandedStruct:             false,
 		IgnoredTypes:               registerer.SchemaIgnoredTypes(),
-		TypeMapper:                 registerer.SchemaTypeMap(),
+		// Types which provide or marshal themselves are documented by their schemas or JSON, unless mapped,
+		// types declared with typed constants by their values, if enabled, and unsupported types by the fallback schema.
+		TypeMapper: ComposeSchemaTypeMaps(provided.typeMap(), registerer.SchemaTypeMap(),
+			marshalerSchemaTypeMap(issues.warn), enums, unsupportedSchemaTypeMap(issues.warn)),
 	}
 
 	jsch := rflctr.ReflectFromType(ty)
+	if err := issues.err(); err != nil {
+		return schema, err
+	}
+	fields := reflectedStructFields
+	if encoder, ok := registerer.(encodingJSONRegisterer); ok && encoder.encodingJSON() {
+		fields = encodingJSONStructFields
+		applyEncodingJSON(jsch, ty, func(t reflect.Type) *jsonschema.Type {
+			s := rflctr.ReflectFromType(t)
+			for name, def := range s.Definitions {
+				if _, ok := jsch.Definitions[name]; !ok {
+					jsch.Definitions[name] = def
+				}
+			}
+			return s.Type
+		})
+	}
+	if tagger, ok := registerer.(SchemaValidationTagsRegisterer); ok {
+		applyValidationTags(jsch, ty, tagger.SchemaValidationTags(), fields, requiresDefault(registerer.SchemaMutations(ty)))
+	}
 
 	// Poor man's glue.
 	// Need to get the type from the go struct -> json reflector package
@@ -280,7 +381,11 @@ func buildJSONSchemaObject(registerer SchemaRegisterer, r reflect.Value, m refle
 		return schema, err
 	}
 
-	err = json.Unmarshal(mm, &schema)
+	withProvided, err := provided.replace(mm)
+	if err != nil {
+		return schema, err
+	}
+	err = json.Unmarshal(withProvided, &schema)
 	if err != nil {
 		return schema, fmt.Errorf("unmarshal jsch error: %v\n\n%s", err, string(mm))
 	}
@@ -308,11 +413,14 @@ func buildJSONSchemaObject(registerer SchemaRegisterer, r reflect.Value, m refle
 		if err != nil {
 			return schema, err
 		}
+		out, err = provided.replace(out)
+		if err != nil {
+			return schema, err
+		}
 
 		schema = meta_schema.JSONSchema{} // Reinitialize
 		err = json.Unmarshal(out, &schema)
 		if err != nil {
-			fmt.Println(string(out))
 			return schema, fmt.Errorf("error: %v, schema: %s", err, string(out))
 		}
 	}
@@ -326,6 +434,46 @@ func buildJSONSchemaObject(registerer SchemaRegisterer, r reflect.Value, m refle
 	return schema, nil
 }
 
+// unmarshalJSONSchema unmarshals a schema, working around the generated meta_schema.Type,
+// which always marshals as an array, and whose UnmarshalJSON sets both its fields for an array,
+// so that a marshaled schema would otherwise unmarshal to one marshaling as an array of arrays.
+// Types can't be marshaled as arrays of more than one type; use anyOf instead.
+func unmarshalJSONSchema(b []byte) (meta_schema.JSONSchema, error) {
+	schema := meta_schema.JSONSchema{}
+	if err := json.Unmarshal(b, &schema); err != nil {
+		return schema, err
+	}
+	fixSchemaTypes(reflect.ValueOf(&schema))
+	return schema, nil
+}
+
+var schemaTypeType = reflect.TypeOf(meta_schema.Type{})
+
+func fixSchemaTypes(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			fixSchemaTypes(v.Elem())
+		}
+	case reflect.Struct:
+		if v.Type() == schemaTypeType {
+			t := v.Addr().Interface().(*meta_schema.Type)
+			if t.ArrayOfSimpleTypes != nil && len(*t.ArrayOfSimpleTypes) == 1 {
+				t.SimpleTypes = &(*t.ArrayOfSimpleTypes)[0]
+				t.ArrayOfSimpleTypes = nil
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			fixSchemaTypes(v.Field(i))
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			fixSchemaTypes(v.Index(i))
+		}
+	}
+}
+
 func isExportedMethod(method reflect.Method) bool {
 	return method.PkgPath == ""
 }
@@ -1,12 +1,14 @@
 package go_openrpc_reflect
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"go/ast"
 	"net"
 	"reflect"
 	"sort"
+	"sync"
 
 	"github.com/alecthomas/jsonschema"
 	"github.com/go-openapi/spec"
@@ -97,6 +99,12 @@ type SchemaRegisterer interface {
 //	Ethereum
 //)
 
+// MethodFilter decides whether a method is documented for a caller.
+// The context is the one passed to DiscoverContext, eg. the discovery request's,
+// and carries whatever the application knows of the caller, like its auth claims.
+// Filters see the method after it is built, so its name and tags are available,
+// together with the receiver (and its registered name) which implements it.
+type MethodFilter func(ctx context.Context, receiverName string, receiver interface{}, method meta_schema.MethodObject) bool
 
 type Document struct {
 	meta          MetaRegisterer
@@ -104,6 +112,24 @@ type Document struct {
 	receiverNames []string
 	receivers     []interface{}
 	listeners     []net.Listener
+	methodFilter  MethodFilter
+	links         map[string][]meta_schema.LinkObject
+	inferLinks    bool
+	version       meta_schema.Openrpc
+
+	// servers are registered servers which aren't listeners.
+	servers []meta_schema.ServerObject
+
+	// receiverTransports, listenerTransports and serverTransports are parallel to receivers, listeners and servers,
+	// as are receiverListeners, the listeners receivers are bound to.
+	receiverTransports [][]string
+	receiverListeners  [][]net.Listener
+	listenerTransports []string
+	serverTransports   []string
+
+	// schemaWarnings are those of the last discovery, see SchemaWarnings.
+	schemaWarningsMu sync.Mutex
+	schemaWarnings   []SchemaWarning
 }
 
 //func (d *Document) RPCDiscover(kind Service) (receiver interface{}) {
@@ -121,21 +147,11 @@ func (d *Document) RegisterReceiver(receiver interface{}) {
 }
 
 func (d *Document) RegisterReceiverName(name string, receiver interface{}) {
-	if d.receivers == nil {
-		d.receivers = []interface{}{}
-	}
-	if d.receiverNames == nil {
-		d.receiverNames = []string{}
-	}
-	d.receiverNames = append(d.receiverNames, name)
-	d.receivers = append(d.receivers, receiver)
+	d.RegisterReceiverTransports(name, receiver)
 }
 
 func (d *Document) RegisterListener(listener net.Listener) {
-	if d.listeners == nil {
-		d.listeners = []net.Listener{}
-	}
-	d.listeners = append(d.listeners, listener)
+	d.RegisterListenerTransport(listener, "")
 }
 
 func (d *Document) WithMeta(meta MetaRegisterer) *Document {
@@ -148,26 +164,67 @@ func (d *Document) WithReflector(reflector ReceiverRegisterer) *Document {
 	return d
 }
 
+// WithMethodFilter sets a filter deciding which methods are documented for a caller.
+// Methods for which the filter returns false are left out of the document.
+func (d *Document) WithMethodFilter(filter MethodFilter) *Document {
+	d.methodFilter = filter
+	return d
+}
+
 var errMissingInterface = errors.New("missing interface")
 
+// Discover builds the document for a caller with a background context.
 func (d *Document) Discover() (*meta_schema.OpenrpcDocument, error) {
+	return d.DiscoverContext(context.Background())
+}
+
+// DiscoverContext builds the document for the caller of the context,
+// leaving out the methods which the document's method filter, if any, hides from them.
+// If the context has a transport, the document is that of the transport.
+func (d *Document) DiscoverContext(ctx context.Context) (*meta_schema.OpenrpcDocument, error) {
+	out, err := d.discover(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if d.version != "" {
+		if err := ValidateDocument(out); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// discover builds the document, collecting the extensions of its methods in exts if it isn't nil
+// and the reflector collects them.
+func (d *Document) discover(ctx context.Context, exts methodExtensions) (*meta_schema.OpenrpcDocument, error) {
 
 	if d.meta == nil {
 		return nil, fmt.Errorf("meta: %v", errMissingInterface)
 	}
 
-	openRPCDocumentVersion := meta_schema.OpenrpcEnum0
+	openRPCDocumentVersion := OpenRPCVersion1_2
+	if d.version != "" {
+		openRPCDocumentVersion = d.version
+	}
 	out := &meta_schema.OpenrpcDocument{
 		Openrpc:      &openRPCDocumentVersion,
 		Info:         d.meta.GetInfo()(),         // This will panic if the developer misuses it (leaves it nil).
 		ExternalDocs: d.meta.GetExternalDocs()(), // This too.
 	}
 
+	transport, _ := TransportFromContext(ctx)
+
 	getServersFn := d.meta.GetServers()
-	servers, err := getServersFn(d.listeners)
+	servers, err := getServersFn(d.transportListeners(transport))
 	if err != nil {
 		return nil, fmt.Errorf("listener error: %w", err)
 	}
+	if registered := d.transportServers(transport); len(registered) > 0 {
+		if servers == nil {
+			servers = &meta_schema.Servers{}
+		}
+		*servers = append(*servers, registered...)
+	}
 	out.Servers = servers
 
 	// Return no error if no receivers registered.
@@ -180,25 +237,147 @@ func (d *Document) Discover() (*meta_schema.OpenrpcDocument, error) {
 		return out, nil
 	}
 
+	// The schema warnings of this discovery are collected by a copy of the reflector.
+	warnings := &schemaWarnings{}
+	reflector := withSchemaWarnings(d.reflector, warnings)
+
 	// Iterate all registered receivers (aka 'modules'),
 	// building and collecting eligible methods for each.
 	methods := []meta_schema.MethodObject{}
+	documented := []int{}
 	for i, rec := range d.receivers {
+		if !onTransport(d.receiverTransports[i], transport) {
+			continue
+		}
+		methodServers, served, err := d.receiverServers(i, transport)
+		if err != nil {
+			return nil, fmt.Errorf("listener error: %w", err)
+		}
+		if !served {
+			continue
+		}
 		name := d.receiverNames[i]
-		ms, err := d.reflector.ReceiverMethods(name, rec)
+		var ms []meta_schema.MethodObject
+		if extender, ok := reflector.(receiverExtensionsRegisterer); ok && exts != nil {
+			ms, err = extender.receiverMethodsExtensions(name, rec, exts)
+		} else {
+			ms, err = reflector.ReceiverMethods(name, rec)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("receiver method error: %w", err)
+		}
+		documented = append(documented, i)
+		for _, m := range ms {
+			if d.methodFilter != nil && !d.methodFilter(ctx, name, rec, m) {
+				continue
+			}
+			if m.Servers == nil {
+				m.Servers = methodServers
+			}
+			methods = append(methods, m)
+		}
+	}
+
+	if registerer, ok := reflector.(namespaceMethodsRegisterer); ok {
+		methods, err = d.mergeNamespaceMethods(ctx, registerer, methods, documented, transport, exts)
 		if err != nil {
 			return nil, fmt.Errorf("receiver method error: %w", err)
 		}
-		methods = append(methods, ms...)
 	}
 
+	d.schemaWarningsMu.Lock()
+	d.schemaWarnings = warnings.warnings
+	d.schemaWarningsMu.Unlock()
+
 	sort.Slice(methods, func(i, j int) bool {
 		return *methods[i].Name < *methods[j].Name
 	})
 
+	// Links are set once all methods are known.
+	d.linkMethods(methods)
+	notificationResults(openRPCDocumentVersion, methods)
+
 	// Assign by slice address.
 	m := meta_schema.Methods(methods)
 	out.Methods = &m
 
 	return out, nil
 }
+
+// namespaceMethodsRegisterer is implemented by reflectors which document some methods once per namespace
+// rather than per receiver, eg. go-ethereum's subscribe and unsubscribe methods, which serve the
+// subscriptions of all of a namespace's receivers.
+type namespaceMethodsRegisterer interface {
+	methodNamespace(name string, receiver interface{}) string
+	namespaceMethods(namespace string, receivers []interface{}, exts methodExtensions) ([]meta_schema.MethodObject, error)
+}
+
+// mergeNamespaceMethods replaces the namespace methods documented by each of the receivers
+// sharing a namespace with those of the namespace, filtered and served as its first receiver's.
+func (d *Document) mergeNamespaceMethods(ctx context.Context, registerer namespaceMethodsRegisterer, methods []meta_schema.MethodObject, documented []int, transport string, exts methodExtensions) ([]meta_schema.MethodObject, error) {
+	namespaces, byNamespace := d.receiverNamespaces(registerer, documented)
+	for _, ns := range namespaces {
+		indexes := byNamespace[ns]
+		if len(indexes) < 2 {
+			continue
+		}
+		ms, err := registerer.namespaceMethods(ns, d.receiversAt(indexes), exts)
+		if err != nil {
+			return nil, err
+		}
+		if len(ms) == 0 {
+			continue
+		}
+		replaced := map[string]bool{}
+		for _, m := range ms {
+			replaced[string(*m.Name)] = true
+		}
+		kept := methods[:0]
+		for _, m := range methods {
+			if !replaced[string(*m.Name)] {
+				kept = append(kept, m)
+			}
+		}
+		methods = kept
+
+		first := indexes[0]
+		methodServers, _, err := d.receiverServers(first, transport)
+		if err != nil {
+			return nil, fmt.Errorf("listener error: %w", err)
+		}
+		for _, m := range ms {
+			if d.methodFilter != nil && !d.methodFilter(ctx, d.receiverNames[first], d.receivers[first], m) {
+				continue
+			}
+			if m.Servers == nil {
+				m.Servers = methodServers
+			}
+			methods = append(methods, m)
+		}
+	}
+	return methods, nil
+}
+
+// receiverNamespaces groups the indexes of receivers by the namespace of their methods,
+// returning the namespaces in order of first registration.
+func (d *Document) receiverNamespaces(registerer namespaceMethodsRegisterer, indexes []int) ([]string, map[string][]int) {
+	namespaces := []string{}
+	byNamespace := map[string][]int{}
+	for _, i := range indexes {
+		ns := registerer.methodNamespace(d.receiverNames[i], d.receivers[i])
+		if _, ok := byNamespace[ns]; !ok {
+			namespaces = append(namespaces, ns)
+		}
+		byNamespace[ns] = append(byNamespace[ns], i)
+	}
+	return namespaces, byNamespace
+}
+
+// receiversAt returns the receivers at the indexes.
+func (d *Document) receiversAt(indexes []int) []interface{} {
+	out := make([]interface{}, len(indexes))
+	for j, i := range indexes {
+		out[j] = d.receivers[i]
+	}
+	return out
+}
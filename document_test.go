@@ -1,6 +1,7 @@
 package go_openrpc_reflect
 
 import (
+	"context"
 	"encoding/json"
 	"net"
 	"regexp"
@@ -70,7 +71,7 @@ func TestDocument_Discover(t *testing.T) {
 			"openrpc":                 "1.2.6",
 			"info.title":              "Calculator API",
 			"info.version":            regexp.MustCompile(time.Now().Format("2006")),
-			"servers.0.url":           listener.Addr().String(),
+			"servers.0.url":           "http://" + listener.Addr().String(),
 			"methods.#":               float64(5),
 			"methods.0.name":          "CalculatorRPC.Add",
 			"methods.0.params.#":      float64(1),
@@ -111,7 +112,7 @@ func TestDocument_Discover(t *testing.T) {
 			"openrpc":                        "1.2.6",
 			"info.title":                     "Calculator API",
 			"info.version":                   regexp.MustCompile(time.Now().Format("2006")),
-			"servers.0.url":                  listener.Addr().String(),
+			"servers.0.url":                  "http://" + listener.Addr().String(),
 			"methods.#":                      float64(13),
 			"methods.0.name":                 "calculator_add",
 			"methods.0.params.#":             float64(2),
@@ -125,3 +126,189 @@ func TestDocument_Discover(t *testing.T) {
 		testJSON(t, b, jsonTests)
 	})
 }
+
+type testClaimsKey struct{}
+
+func TestDocument_DiscoverContext(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(StandardReflector)
+	d.RegisterReceiverName("calculator", new(fakearithmetic.CalculatorRPC))
+	d.RegisterReceiverName("admin", new(fakearithmetic.CalculatorRPC))
+
+	// Only operators are shown the admin receiver's methods.
+	d.WithMethodFilter(func(ctx context.Context, receiverName string, receiver interface{}, method meta_schema.MethodObject) bool {
+		if receiverName != "admin" {
+			return true
+		}
+		role, _ := ctx.Value(testClaimsKey{}).(string)
+		return role == "operator"
+	})
+
+	out, err := d.Discover()
+	if !assert.NoError(t, err) {
+		t.Fatal("discover error, fatal")
+	}
+	b, _ := json.Marshal(out)
+	testJSON(t, b, map[string]interface{}{
+		"methods.#":      float64(5),
+		"methods.0.name": "calculator.Add",
+	})
+
+	ctx := context.WithValue(context.Background(), testClaimsKey{}, "operator")
+	out, err = d.DiscoverContext(ctx)
+	if !assert.NoError(t, err) {
+		t.Fatal("discover error, fatal")
+	}
+	b, _ = json.Marshal(out)
+	testJSON(t, b, map[string]interface{}{
+		"methods.#":      float64(10),
+		"methods.0.name": "admin.Add",
+	})
+}
+
+func TestDocument_DiscoverContext_Transport(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(StandardReflector)
+
+	httpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		t.Fatal("listen error, fatal")
+	}
+	defer httpListener.Close()
+	ipcListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		t.Fatal("listen error, fatal")
+	}
+	defer ipcListener.Close()
+
+	d.RegisterListenerTransport(httpListener, "http")
+	d.RegisterListenerTransport(ipcListener, "ipc")
+	d.RegisterReceiverName("calculator", new(fakearithmetic.CalculatorRPC))
+	d.RegisterReceiverTransports("admin", new(fakearithmetic.CalculatorRPC), "ipc")
+
+	cases := []struct {
+		transport string
+		want      map[string]interface{}
+	}{
+		// Without a transport, only what's registered against none is discovered.
+		{"", map[string]interface{}{
+			"servers":        nil,
+			"methods.#":      float64(5),
+			"methods.0.name": "calculator.Add",
+		}},
+		{"http", map[string]interface{}{
+			"servers.#":      float64(1),
+			"servers.0.url":  "http://" + httpListener.Addr().String(),
+			"methods.#":      float64(5),
+			"methods.0.name": "calculator.Add",
+		}},
+		{"ipc", map[string]interface{}{
+			"servers.#":      float64(1),
+			"servers.0.url":  "http://" + ipcListener.Addr().String(),
+			"methods.#":      float64(10),
+			"methods.0.name": "admin.Add",
+		}},
+	}
+	for _, c := range cases {
+		ctx := context.Background()
+		if c.transport != "" {
+			ctx = ContextWithTransport(ctx, c.transport)
+		}
+		out, err := d.DiscoverContext(ctx)
+		if !assert.NoError(t, err, c.transport) {
+			continue
+		}
+		b, _ := json.Marshal(out)
+		testJSON(t, b, c.want)
+	}
+}
+
+func TestDocument_RegisterServer(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(StandardReflector)
+	d.RegisterReceiver(new(fakearithmetic.CalculatorRPC))
+
+	url, name := "https://rpc.example.com", "public"
+	d.RegisterServer(meta_schema.ServerObject{
+		Url:  (*meta_schema.ServerObjectUrl)(&url),
+		Name: (*meta_schema.ServerObjectName)(&name),
+	})
+	ipcURL := "unix:///var/run/node.ipc"
+	d.RegisterServerTransport(meta_schema.ServerObject{Url: (*meta_schema.ServerObjectUrl)(&ipcURL)}, "ipc")
+
+	out, err := d.DiscoverContext(ContextWithTransport(context.Background(), "http"))
+	if !assert.NoError(t, err) {
+		t.Fatal("discover error, fatal")
+	}
+	b, _ := json.Marshal(out)
+	testJSON(t, b, map[string]interface{}{
+		"servers.#":      float64(1),
+		"servers.0.url":  url,
+		"servers.0.name": name,
+	})
+
+	out, err = d.DiscoverContext(ContextWithTransport(context.Background(), "ipc"))
+	if !assert.NoError(t, err) {
+		t.Fatal("discover error, fatal")
+	}
+	b, _ = json.Marshal(out)
+	testJSON(t, b, map[string]interface{}{
+		"servers.#":     float64(2),
+		"servers.1.url": ipcURL,
+	})
+
+	out, err = d.Discover()
+	if !assert.NoError(t, err) {
+		t.Fatal("discover error, fatal")
+	}
+	b, _ = json.Marshal(out)
+	testJSON(t, b, map[string]interface{}{
+		"servers.#":     float64(1),
+		"servers.0.url": url,
+	})
+}
+
+func TestDocument_RegisterReceiverListeners(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(StandardReflector)
+
+	full, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		t.Fatal("listen error, fatal")
+	}
+	defer full.Close()
+	archive, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		t.Fatal("listen error, fatal")
+	}
+	defer archive.Close()
+
+	d.RegisterListenerTransport(full, "http")
+	d.RegisterListenerTransport(archive, "ipc")
+	d.RegisterReceiverName("calculator", new(fakearithmetic.CalculatorRPC))
+	d.RegisterReceiverListeners("archive", new(fakearithmetic.CalculatorRPC), archive)
+
+	out, err := d.DiscoverContext(ContextWithTransport(context.Background(), "ipc"))
+	if !assert.NoError(t, err) {
+		t.Fatal("discover error, fatal")
+	}
+	b, _ := json.Marshal(out)
+	testJSON(t, b, map[string]interface{}{
+		"servers.#":               float64(1),
+		"methods.#":               float64(10),
+		"methods.0.name":          "archive.Add",
+		"methods.0.servers.#":     float64(1),
+		"methods.0.servers.0.url": "http://" + archive.Addr().String(),
+		"methods.5.name":          "calculator.Add",
+		"methods.5.servers":       nil,
+	})
+
+	// The archive endpoint isn't served over http, nor discovered without a transport.
+	for _, ctx := range []context.Context{ContextWithTransport(context.Background(), "http"), context.Background()} {
+		out, err = d.DiscoverContext(ctx)
+		if !assert.NoError(t, err) {
+			t.Fatal("discover error, fatal")
+		}
+		b, _ = json.Marshal(out)
+		testJSON(t, b, map[string]interface{}{
+			"methods.#":      float64(5),
+			"methods.0.name": "calculator.Add",
+		})
+	}
+}
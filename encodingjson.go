@@ -0,0 +1,256 @@
+package go_openrpc_reflect
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/iancoleman/orderedmap"
+)
+
+// encodingJSONRegisterer is implemented by reflectors which may document schemas as encoding/json encodes values,
+// see StandardReflectorT.EncodingJSON.
+type encodingJSONRegisterer interface {
+	encodingJSON() bool
+}
+
+func (c *StandardReflectorT) encodingJSON() bool {
+	return c.EncodingJSON
+}
+
+// jsonField is a field encoding/json encodes, as resolved by its rules for embedded structs.
+type jsonField struct {
+	reflectedField
+	index     []int
+	tagged    bool
+	omitEmpty bool
+	quoted    bool
+}
+
+// encodingJSONFields returns the fields of a struct which encoding/json encodes, in order:
+// exported fields not tagged "-", and the fields of embedded structs without names in their json tags,
+// of which the shallowest field of a name is encoded, or the tagged one of those, and none if that's ambiguous.
+func encodingJSONFields(t reflect.Type) []jsonField {
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+	fields := []jsonField{}
+	current := []embedded{}
+	next := []embedded{{typ: t}}
+	visited := map[reflect.Type]bool{}
+	for len(next) > 0 {
+		current, next = next, nil
+		count := map[reflect.Type]int{}
+		for _, e := range current {
+			count[e.typ]++
+		}
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				if sf.Anonymous {
+					ft := derefType(sf.Type)
+					if sf.PkgPath != "" && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if sf.PkgPath != "" {
+					continue
+				}
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				opts := strings.Split(tag, ",")
+				name := opts[0]
+				if !validJSONTagName(name) {
+					name = ""
+				}
+				index := append(append([]int{}, e.index...), i)
+
+				ft := sf.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if name != "" || !sf.Anonymous || ft.Kind() != reflect.Struct {
+					f := jsonField{
+						reflectedField: reflectedField{name: name, field: sf},
+						index:          index,
+						tagged:         name != "",
+					}
+					if f.name == "" {
+						f.name = sf.Name
+					}
+					for _, opt := range opts[1:] {
+						switch opt {
+						case "omitempty":
+							f.omitEmpty = true
+						case "string":
+							switch ft.Kind() {
+							case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+								reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+								reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+								f.quoted = true
+							}
+						}
+					}
+					fields = append(fields, f)
+					if count[e.typ] > 1 {
+						// Fields of a struct embedded twice at a depth are ambiguous, and annihilate each other.
+						fields = append(fields, f)
+					}
+					continue
+				}
+				next = append(next, embedded{typ: ft, index: index})
+			}
+		}
+	}
+
+	// Keep the dominant field of each name.
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].name != fields[j].name {
+			return fields[i].name < fields[j].name
+		}
+		if len(fields[i].index) != len(fields[j].index) {
+			return len(fields[i].index) < len(fields[j].index)
+		}
+		return fields[i].tagged && !fields[j].tagged
+	})
+	out := []jsonField{}
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+		group := fields[i:j]
+		i = j
+		if len(group) > 1 && len(group[0].index) == len(group[1].index) && group[0].tagged == group[1].tagged {
+			continue
+		}
+		out = append(out, group[0])
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i].index, out[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return out
+}
+
+// validJSONTagName reports whether encoding/json accepts the name of a json tag.
+func validJSONTagName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
+
+// encodingJSONStructFields returns the fields of a struct which encoding/json encodes, as validation tags are looked up by.
+func encodingJSONStructFields(t reflect.Type) []reflectedField {
+	out := []reflectedField{}
+	for _, f := range encodingJSONFields(t) {
+		out = append(out, f.reflectedField)
+	}
+	return out
+}
+
+// applyEncodingJSON redocuments the properties of the structs reflected in the schema as encoding/json encodes them:
+// properties are the fields encoding/json encodes, those which aren't omitempty or pointers are required,
+// pointers, slices and maps are nullable, and fields tagged ",string" are strings.
+// Properties which alecthomas/jsonschema didn't reflect as encoding/json encodes them are reflected by reflectType.
+func applyEncodingJSON(schema *jsonschema.Schema, ty reflect.Type, reflectType func(reflect.Type) *jsonschema.Type) {
+	visited := map[reflect.Type]bool{}
+	var visit func(t reflect.Type)
+	visit = func(t reflect.Type) {
+		t = elemType(t)
+		if t.Kind() != reflect.Struct || t.Name() == "" || visited[t] {
+			return
+		}
+		visited[t] = true
+		def, ok := schema.Definitions[t.Name()]
+		if !ok {
+			return
+		}
+
+		// The fields alecthomas/jsonschema reflected, by property name.
+		reflected := map[string]reflectedField{}
+		for _, f := range reflectedStructFields(t) {
+			reflected[f.name] = f
+		}
+
+		properties := orderedmap.New()
+		required := []string{}
+		for _, f := range encodingJSONFields(t) {
+			var property *jsonschema.Type
+			if r, ok := reflected[f.name]; ok && def.Properties != nil && reflect.DeepEqual(r.field.Index, f.field.Index) && r.field.Type == f.field.Type {
+				if v, ok := def.Properties.Get(f.name); ok {
+					property = v.(*jsonschema.Type)
+				}
+			}
+			if property == nil {
+				property = reflectType(f.field.Type)
+			}
+			if f.quoted {
+				property = &jsonschema.Type{Type: "string", Description: property.Description}
+			}
+			switch f.field.Type.Kind() {
+			case reflect.Ptr, reflect.Slice, reflect.Map:
+				property = &jsonschema.Type{AnyOf: []*jsonschema.Type{property, {Type: "null"}}}
+			}
+			properties.Set(f.name, property)
+
+			if !f.omitEmpty && f.field.Type.Kind() != reflect.Ptr || hasJSONSchemaRequiredTag(f.field) {
+				required = append(required, f.name)
+			}
+			visit(f.field.Type)
+		}
+		def.Properties = properties
+		def.Required = required
+	}
+	visit(ty)
+}
+
+func hasJSONSchemaRequiredTag(f reflect.StructField) bool {
+	for _, tag := range strings.Split(f.Tag.Get("jsonschema"), ",") {
+		if tag == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// elemType returns the type of the elements of pointers, slices, arrays and maps, or the type.
+func elemType(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+			continue
+		}
+		return t
+	}
+}
+
+// nonNullSchema returns the schema of a nullable schema, ie. of anyOf [schema, {"type": "null"}], or the schema.
+func nonNullSchema(s *jsonschema.Type) *jsonschema.Type {
+	if len(s.AnyOf) == 2 && s.AnyOf[1].Type == "null" {
+		return s.AnyOf[0]
+	}
+	return s
+}
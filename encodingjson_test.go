@@ -0,0 +1,90 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type EncodingJSONBase struct {
+	ID      string `json:"id"`
+	Created int64  `json:"created,omitempty"`
+}
+
+type EncodingJSONAudit struct {
+	ID     string `json:"id"`
+	Author string `json:"author"`
+}
+
+type EncodingJSONName string
+
+type EncodingJSONItem struct {
+	EncodingJSONBase
+	*EncodingJSONAudit
+	EncodingJSONName
+
+	Price    float64           `json:"price,string"`
+	Note     *string           `json:"note"`
+	Tags     []string          `json:"tags" validate:"max=3"`
+	Attrs    map[string]string `json:"attrs,omitempty"`
+	Secret   string            `json:"-"`
+	Quantity int
+	internal int
+}
+
+func TestEncodingJSON(t *testing.T) {
+	reflector := &StandardReflectorT{EncodingJSON: true}
+	schema, err := buildJSONSchemaObject(reflector, reflect.Value{}, reflect.Method{}, nil, reflect.TypeOf(EncodingJSONItem{}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, _ := json.Marshal(schema)
+	t.Log(string(b))
+
+	// The embedded structs' ids are both tagged, at the same depth, so neither is encoded.
+	testJSON(t, b, map[string]interface{}{
+		"properties.id":                    nil,
+		"properties.Secret":                nil,
+		"properties.internal":              nil,
+		"required.#":                       float64(5),
+		"required.0":                       "author",
+		"required.4":                       "Quantity",
+		"properties.price.type":            "string",
+		"properties.note.anyOf.#":          float64(2),
+		"properties.note.anyOf.0.type":     "string",
+		"properties.note.anyOf.1.type":     "null",
+		"properties.tags.anyOf.0.type":     "array",
+		"properties.tags.anyOf.0.maxItems": float64(3),
+		"properties.attrs.anyOf.1.type":    "null",
+		"properties.EncodingJSONName.type": "string",
+		"properties.Quantity.type":         "integer",
+	})
+}
+
+func TestEncodingJSONFields(t *testing.T) {
+	type inner struct {
+		A string
+		B string `json:"b"`
+	}
+	type outer struct {
+		inner
+		B      string
+		Tagged inner `json:"tagged"`
+	}
+	names := []string{}
+	for _, f := range encodingJSONFields(reflect.TypeOf(outer{})) {
+		names = append(names, f.name)
+	}
+	// Names are case sensitive, so B doesn't hide inner's b.
+	assert.Equal(t, []string{"A", "b", "B", "tagged"}, names)
+}
+
+func TestEncodingJSONFields_Embedded(t *testing.T) {
+	names := []string{}
+	for _, f := range encodingJSONFields(reflect.TypeOf(EncodingJSONItem{})) {
+		names = append(names, f.name)
+	}
+	assert.Equal(t, []string{"created", "author", "EncodingJSONName", "price", "note", "tags", "attrs", "Quantity"}, names)
+}
@@ -0,0 +1,303 @@
+package go_openrpc_reflect
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/jsonschema"
+)
+
+// enumConst is a typed constant of an enum type.
+type enumConst struct {
+	name  string
+	value interface{}
+	doc   string
+}
+
+// enumsRegisterer is implemented by reflectors which may document types declared with typed constants as enums,
+// see StandardReflectorT.Enums.
+type enumsRegisterer interface {
+	schemaEnums() bool
+}
+
+func (c *StandardReflectorT) schemaEnums() bool {
+	return c.Enums
+}
+
+// enumConstsKey keys the constants of a type looked up, which depend on whether its package's test files are parsed.
+type enumConstsKey struct {
+	ty    reflect.Type
+	tests bool
+}
+
+// enumConsts caches the constants of the types looked up, keyed by enumConstsKey,
+// including those of types without them, which most are.
+var enumConsts sync.Map
+
+// enumSchemaTypeMap returns a map documenting named string and integer types declared with two or more typed
+// constants, eg. const ( StatusOpen OrderStatus = "open" ), as enums of the constants' values, in order of declaration,
+// described by the constants' doc comments. Types of bit flags, whose constants are built by shifts, eg. 1 << iota,
+// aren't enums, as their constants' combinations are values too.
+// The constants are found by parsing the type's package, as methods' are.
+func enumSchemaTypeMap(m reflect.Method) SchemaTypeMap {
+	return func(ty reflect.Type) *jsonschema.Type {
+		var schemaType string
+		switch ty.Kind() {
+		case reflect.String:
+			schemaType = "string"
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			schemaType = "integer"
+		default:
+			return nil
+		}
+		if ty.Name() == "" || ty.PkgPath() == "" {
+			return nil
+		}
+
+		key := enumConstsKey{ty: ty, tests: methodInTestFile(m)}
+		cached, ok := enumConsts.Load(key)
+		if !ok {
+			cached, _ = enumConsts.LoadOrStore(key, typedConsts(astPackageFiles(m, ty), ty))
+		}
+		consts := cached.([]enumConst)
+		if len(consts) < 2 {
+			return nil
+		}
+
+		schema := &jsonschema.Type{Type: schemaType}
+		descriptions := []string{}
+		for _, c := range consts {
+			schema.Enum = append(schema.Enum, c.value)
+			if c.doc != "" {
+				descriptions = append(descriptions, fmt.Sprintf("- `%v`: %s", c.value, c.doc))
+			}
+		}
+		if len(descriptions) > 0 {
+			// Set as an extra, as the reflector resets the descriptions of struct fields' types
+			// to their jsonschema_description tags.
+			schema.Extras = map[string]interface{}{"description": strings.Join(descriptions, "\n")}
+		}
+		return schema
+	}
+}
+
+// typedConsts returns the constants of the type declared in the files, with unique values,
+// in order of declaration. Constants whose values can't be evaluated are skipped.
+// It returns none for types of bit flags, any of whose constants is built by a shift.
+func typedConsts(files []*ast.File, ty reflect.Type) []enumConst {
+	e := &constEvaluator{
+		specs:  map[string]constSpec{},
+		values: map[string]constant.Value{},
+	}
+	ordered := []constSpec{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			// Specs without values repeat the previous spec's type and values.
+			var typ ast.Expr
+			var values []ast.Expr
+			for iota, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				if vs.Type != nil || len(vs.Values) > 0 {
+					typ, values = vs.Type, vs.Values
+				}
+				for i, name := range vs.Names {
+					if name.Name == "_" || i >= len(values) {
+						continue
+					}
+					cs := constSpec{name: name.Name, typ: typ, value: values[i], iota: iota, doc: constDoc(vs)}
+					if cs.typ == nil {
+						cs.typ = conversionType(cs.value)
+					}
+					e.specs[cs.name] = cs
+					ordered = append(ordered, cs)
+				}
+			}
+		}
+	}
+
+	out := []enumConst{}
+	seen := map[interface{}]bool{}
+	for _, cs := range ordered {
+		if id, ok := cs.typ.(*ast.Ident); !ok || id.Name != ty.Name() {
+			continue
+		}
+		if shifts(cs.value) {
+			return nil
+		}
+		v := e.eval(cs.name)
+		if v == nil {
+			continue
+		}
+		var value interface{}
+		switch ty.Kind() {
+		case reflect.String:
+			if v.Kind() != constant.String {
+				continue
+			}
+			value = constant.StringVal(v)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			u, exact := constant.Uint64Val(constant.ToInt(v))
+			if !exact {
+				continue
+			}
+			value = u
+		default:
+			i, exact := constant.Int64Val(constant.ToInt(v))
+			if !exact {
+				continue
+			}
+			value = i
+		}
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		out = append(out, enumConst{name: cs.name, value: value, doc: cs.doc})
+	}
+	return out
+}
+
+// shifts tells whether a constant expression shifts, eg. 1 << iota.
+func shifts(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if b, ok := n.(*ast.BinaryExpr); ok && (b.Op == token.SHL || b.Op == token.SHR) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// constDoc returns a constant's doc comment, or its line comment, on one line.
+func constDoc(vs *ast.ValueSpec) string {
+	group := vs.Doc
+	if group == nil {
+		group = vs.Comment
+	}
+	if group == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(group.Text()), " ")
+}
+
+// conversionType returns the type of a conversion, eg. OrderStatus of OrderStatus("open").
+func conversionType(expr ast.Expr) ast.Expr {
+	for {
+		p, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+		expr = p.X
+	}
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		if id, ok := call.Fun.(*ast.Ident); ok {
+			return id
+		}
+	}
+	return nil
+}
+
+type constSpec struct {
+	name  string
+	typ   ast.Expr
+	value ast.Expr
+	iota  int
+	doc   string
+}
+
+// constEvaluator evaluates the constant expressions of a package, without type checking it:
+// literals, iota, references to the package's constants, conversions, and unary and binary operations.
+type constEvaluator struct {
+	specs  map[string]constSpec
+	values map[string]constant.Value
+}
+
+// eval returns the value of the named constant, or nil if it can't be evaluated.
+func (e *constEvaluator) eval(name string) constant.Value {
+	if v, ok := e.values[name]; ok {
+		return v
+	}
+	cs, ok := e.specs[name]
+	if !ok {
+		return nil
+	}
+	e.values[name] = nil // Guards against cycles.
+	v := e.expr(cs.value, cs.iota)
+	e.values[name] = v
+	return v
+}
+
+func (e *constEvaluator) expr(expr ast.Expr, iota int) constant.Value {
+	switch x := expr.(type) {
+	case *ast.BasicLit:
+		v := constant.MakeFromLiteral(x.Value, x.Kind, 0)
+		if v.Kind() == constant.Unknown {
+			return nil
+		}
+		return v
+	case *ast.Ident:
+		switch x.Name {
+		case "iota":
+			return constant.MakeInt64(int64(iota))
+		case "true", "false":
+			return constant.MakeBool(x.Name == "true")
+		}
+		return e.eval(x.Name)
+	case *ast.ParenExpr:
+		return e.expr(x.X, iota)
+	case *ast.CallExpr:
+		// Conversions, eg. OrderStatus("open") or uint8(1).
+		if _, ok := x.Fun.(*ast.Ident); !ok || len(x.Args) != 1 {
+			return nil
+		}
+		return e.expr(x.Args[0], iota)
+	case *ast.UnaryExpr:
+		v := e.expr(x.X, iota)
+		if v == nil {
+			return nil
+		}
+		return safeConstOp(func() constant.Value { return constant.UnaryOp(x.Op, v, 0) })
+	case *ast.BinaryExpr:
+		a, b := e.expr(x.X, iota), e.expr(x.Y, iota)
+		if a == nil || b == nil {
+			return nil
+		}
+		switch x.Op {
+		case token.SHL, token.SHR:
+			s, ok := constant.Uint64Val(b)
+			if !ok {
+				return nil
+			}
+			return safeConstOp(func() constant.Value { return constant.Shift(a, x.Op, uint(s)) })
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			return safeConstOp(func() constant.Value { return constant.MakeBool(constant.Compare(a, x.Op, b)) })
+		}
+		op := x.Op
+		if op == token.QUO && a.Kind() == constant.Int && b.Kind() == constant.Int {
+			op = token.QUO_ASSIGN // Integer division.
+		}
+		return safeConstOp(func() constant.Value { return constant.BinaryOp(a, op, b) })
+	}
+	return nil
+}
+
+// safeConstOp returns the value of a go/constant operation, or nil if it panics on invalid operands.
+func safeConstOp(op func() constant.Value) (v constant.Value) {
+	defer func() {
+		if recover() != nil {
+			v = nil
+		}
+	}()
+	return op()
+}
@@ -0,0 +1,78 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakeenums"
+	"github.com/stretchr/testify/assert"
+)
+
+// enumTestStatus is declared in a _test.go file, which only test binaries are built with.
+type enumTestStatus string
+
+const (
+	enumTestStatusOn  enumTestStatus = "on"
+	enumTestStatusOff enumTestStatus = "off"
+)
+
+type enumTestService struct{}
+
+func (enumTestService) Set(status enumTestStatus) error {
+	return nil
+}
+
+func TestEnumSchemas(t *testing.T) {
+	reflector := &StandardReflectorT{Enums: true}
+	schema, err := buildJSONSchemaObject(reflector, reflect.Value{}, reflect.Method{}, nil, reflect.TypeOf(fakeenums.Order{}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, _ := json.Marshal(schema)
+	t.Log(string(b))
+	testJSON(t, b, map[string]interface{}{
+		"properties.status.type":          "string",
+		"properties.status.enum.#":        float64(3),
+		"properties.status.enum.0":        "open",
+		"properties.status.enum.2":        "cancelled",
+		"properties.status.description":   "- `open`: StatusOpen awaits payment.\n- `paid`: Paid in full.",
+		"properties.history.items.enum.1": "paid",
+		"properties.priority.type":        "integer",
+		"properties.priority.enum.#":      float64(3),
+		"properties.priority.enum.1":      float64(2),
+		"properties.priority.enum.2":      float64(10),
+		"properties.flags.enum":           nil,
+		"properties.flags.type":           "integer",
+		"properties.kind.enum":            nil,
+		"properties.undeclared.enum":      nil,
+		"properties.undeclared.type":      "string",
+	})
+}
+
+func TestEnumSchemas_Disabled(t *testing.T) {
+	schema, err := buildJSONSchemaObject(StandardReflector, reflect.Value{}, reflect.Method{}, nil, reflect.TypeOf(fakeenums.Order{}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, _ := json.Marshal(schema)
+	testJSON(t, b, map[string]interface{}{
+		"properties.status.type": "string",
+		"properties.status.enum": nil,
+	})
+}
+
+func TestEnumSchemas_TestFiles(t *testing.T) {
+	ty := reflect.TypeOf(enumTestStatus(""))
+
+	// Without a method declared in a _test.go file, the package's test files aren't parsed,
+	// and the type has no constants.
+	assert.Nil(t, enumSchemaTypeMap(reflect.Method{})(ty))
+
+	m, _ := reflect.TypeOf(enumTestService{}).MethodByName("Set")
+	schema := enumSchemaTypeMap(m)(ty)
+	if !assert.NotNil(t, schema) {
+		t.FailNow()
+	}
+	assert.Equal(t, []interface{}{"on", "off"}, schema.Enum)
+}
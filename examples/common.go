@@ -1,6 +1,7 @@
 package examples
 
 import (
+	"context"
 	"net"
 	"time"
 
@@ -23,12 +24,45 @@ func (d *RPC) Discover(rpcArg *RPCArg, document *meta_schema.OpenrpcDocument) er
 	return err
 }
 
+// DiscoverExtended serves the document with its specification extensions, if any.
+// Discover serves the plain document, whose type its clients decode.
+func (d *RPC) DiscoverExtended(rpcArg *RPCArg, document *go_openrpc_reflect.ExtendedDocument) error {
+	doc, err := d.Doc.DiscoverExtended()
+	if err != nil {
+		return err
+	}
+	*document = *doc
+	return err
+}
+
 type RPCEthereum struct {
 	Doc *go_openrpc_reflect.Document
+	// RequestTransport, if set, returns the transport a request arrived over, eg. "ipc",
+	// whose document is discovered. With go-ethereum's rpc, it is
+	//
+	//	func(ctx context.Context) string { return rpc.PeerInfoFromContext(ctx).Transport }
+	RequestTransport func(ctx context.Context) string
+}
+
+// Discover takes the request context, which go-ethereum's rpc supplies,
+// so that the document is filtered for the caller and the transport it called over.
+func (d *RPCEthereum) Discover(ctx context.Context) (*meta_schema.OpenrpcDocument, error) {
+	return d.Doc.DiscoverContext(d.requestContext(ctx))
 }
 
-func (d *RPCEthereum) Discover() (*meta_schema.OpenrpcDocument, error) {
-	return d.Doc.Discover()
+// DiscoverExtended is Discover, serving the document with its specification extensions, if any, eg. x-subscription.
+func (d *RPCEthereum) DiscoverExtended(ctx context.Context) (*go_openrpc_reflect.ExtendedDocument, error) {
+	return d.Doc.DiscoverExtendedContext(d.requestContext(ctx))
+}
+
+// requestContext returns the context with the transport the request arrived over, if known.
+func (d *RPCEthereum) requestContext(ctx context.Context) context.Context {
+	if d.RequestTransport != nil {
+		if transport := d.RequestTransport(ctx); transport != "" {
+			return go_openrpc_reflect.ContextWithTransport(ctx, transport)
+		}
+	}
+	return ctx
 }
 
 //var ExampleMetaReflector = &MetaRegistererTester{}
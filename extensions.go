@@ -0,0 +1,202 @@
+package go_openrpc_reflect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"reflect"
+	"strings"
+
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// Extensions are specification extensions: fields prefixed with "x-", which OpenRPC allows
+// on most of its objects, but which the generated meta_schema types have no place for.
+type Extensions map[string]interface{}
+
+// DocumentExtensionsRegisterer is implemented by MetaRegisterers which extend the document object.
+type DocumentExtensionsRegisterer interface {
+	GetExtensions() func() (ext Extensions)
+}
+
+// MethodExtensionsRegisterer is implemented by reflectors which extend method objects.
+type MethodExtensionsRegisterer interface {
+	GetMethodExtensions(r reflect.Value, m reflect.Method, funcDecl *ast.FuncDecl) (Extensions, error)
+}
+
+// ContentDescriptorExtensionsRegisterer is implemented by reflectors which extend the content descriptors
+// of methods' params and results.
+type ContentDescriptorExtensionsRegisterer interface {
+	GetContentDescriptorExtensions(r reflect.Value, m reflect.Method, field *ast.Field) (Extensions, error)
+}
+
+// SchemaExtensionsRegisterer is implemented by reflectors which extend the schemas of content descriptors, eg. with x-go-type.
+type SchemaExtensionsRegisterer interface {
+	GetSchemaExtensions(r reflect.Value, m reflect.Method, field *ast.Field, ty reflect.Type) (Extensions, error)
+}
+
+// ExtendedDocument is an OpenRPC document together with the specification extensions of its objects.
+// It marshals as the document, with the extensions merged into the objects they belong to.
+type ExtendedDocument struct {
+	*meta_schema.OpenrpcDocument
+
+	// Extensions maps JSON pointers of the document's objects, eg. "/methods/0", to their extensions.
+	// The empty pointer is the document itself.
+	Extensions map[string]Extensions
+}
+
+// Extend adds extensions to the object at the JSON pointer.
+func (d *ExtendedDocument) Extend(pointer string, ext Extensions) {
+	if len(ext) == 0 {
+		return
+	}
+	if d.Extensions == nil {
+		d.Extensions = map[string]Extensions{}
+	}
+	if d.Extensions[pointer] == nil {
+		d.Extensions[pointer] = Extensions{}
+	}
+	for k, v := range ext {
+		d.Extensions[pointer][k] = v
+	}
+}
+
+// MarshalJSON implements the json Marshaler interface.
+func (d ExtendedDocument) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(d.OpenrpcDocument)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.Extensions) == 0 {
+		return b, nil
+	}
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	for pointer, ext := range d.Extensions {
+		obj, ok := resolvePointer(doc, pointer).(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("extensions: no object at %q", pointer)
+		}
+		for k, v := range ext {
+			if !strings.HasPrefix(k, "x-") {
+				return nil, fmt.Errorf("extensions: %q at %q: name must begin with x-", k, pointer)
+			}
+			obj[k] = v
+		}
+	}
+	return json.Marshal(doc)
+}
+
+// DiscoverExtended discovers the document, together with the specification extensions of its objects:
+// the document's, if its MetaRegisterer implements DocumentExtensionsRegisterer, and its methods', content descriptors'
+// and schemas', from the reflector's getters (see MethodExtensionsRegisterer, ContentDescriptorExtensionsRegisterer
+// and SchemaExtensionsRegisterer).
+// The documents returned by Discover can't carry extensions, having no place for them.
+func (d *Document) DiscoverExtended() (*ExtendedDocument, error) {
+	return d.DiscoverExtendedContext(context.Background())
+}
+
+// DiscoverExtendedContext is DiscoverExtended for the caller of the context; see DiscoverContext.
+func (d *Document) DiscoverExtendedContext(ctx context.Context) (*ExtendedDocument, error) {
+	exts := methodExtensions{}
+	doc, err := d.discover(ctx, exts)
+	if err != nil {
+		return nil, err
+	}
+	if d.version != "" {
+		if err := ValidateDocument(doc); err != nil {
+			return nil, err
+		}
+	}
+	out := &ExtendedDocument{OpenrpcDocument: doc}
+
+	if registerer, ok := d.meta.(DocumentExtensionsRegisterer); ok {
+		if fn := registerer.GetExtensions(); fn != nil {
+			out.Extend("", fn())
+		}
+	}
+
+	if doc.Methods == nil {
+		return out, nil
+	}
+	for i, m := range *doc.Methods {
+		for pointer, ext := range exts[string(*m.Name)] {
+			out.Extend(fmt.Sprintf("/methods/%d%s", i, pointer), ext)
+		}
+	}
+	return out, nil
+}
+
+// methodExtensions are the extensions of methods, keyed by method name, and then by JSON pointers
+// relative to the method object, eg. "" for the method and "/params/0/schema" for its first param's schema.
+type methodExtensions map[string]map[string]Extensions
+
+// receiverExtensionsRegisterer is implemented by reflectors which collect the extensions of a receiver's methods
+// while building them, as the default reflectors do.
+type receiverExtensionsRegisterer interface {
+	receiverMethodsExtensions(name string, receiver interface{}, exts methodExtensions) ([]meta_schema.MethodObject, error)
+}
+
+// methodObjectExtensions returns the extensions given by a reflector's getters to a method,
+// keyed by JSON pointers relative to the method object.
+func methodObjectExtensions(methodHandler MethodRegisterer, r reflect.Value, m reflect.Method, funcDecl *ast.FuncDecl) (map[string]Extensions, error) {
+	methodRegisterer, _ := methodHandler.(MethodExtensionsRegisterer)
+	cdRegisterer, _ := methodHandler.(ContentDescriptorExtensionsRegisterer)
+	schemaRegisterer, _ := methodHandler.(SchemaExtensionsRegisterer)
+	fielder, _ := methodHandler.(contentDescriptorFielder)
+
+	out := map[string]Extensions{}
+	if methodRegisterer != nil {
+		ext, err := methodRegisterer.GetMethodExtensions(r, m, funcDecl)
+		if err != nil {
+			return nil, err
+		}
+		if len(ext) > 0 {
+			out[""] = ext
+		}
+	}
+	if fielder == nil || cdRegisterer == nil && schemaRegisterer == nil {
+		return out, nil
+	}
+
+	// descriptorExtensions extends a content descriptor and its schema.
+	descriptorExtensions := func(pointer string, f descriptorField) error {
+		if cdRegisterer != nil {
+			ext, err := cdRegisterer.GetContentDescriptorExtensions(r, m, f.field)
+			if err != nil {
+				return err
+			}
+			if len(ext) > 0 {
+				out[pointer] = ext
+			}
+		}
+		if schemaRegisterer != nil {
+			ext, err := schemaRegisterer.GetSchemaExtensions(r, m, f.field, f.ty)
+			if err != nil {
+				return err
+			}
+			if len(ext) > 0 {
+				out[pointer+"/schema"] = ext
+			}
+		}
+		return nil
+	}
+
+	paramsOverridden, resultOverridden := fielder.overridesDescriptors()
+	if !paramsOverridden {
+		for j, f := range fielder.methodParamFields(m, funcDecl) {
+			if err := descriptorExtensions(fmt.Sprintf("/params/%d", j), f); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if f, ok := fielder.methodResultField(m, funcDecl); ok && !resultOverridden {
+		if err := descriptorExtensions("/result", f); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
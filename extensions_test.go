@@ -0,0 +1,96 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"go/ast"
+	"reflect"
+	"testing"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakearithmetic"
+	meta_schema "github.com/open-rpc/meta-schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument_DiscoverExtended_Getters(t *testing.T) {
+	meta := *TestMetaRegisterer
+	meta.GetExtensionsFn = func() Extensions {
+		return Extensions{"x-api-id": "calculator"}
+	}
+
+	reflector := &StandardReflectorT{}
+	reflector.FnGetMethodExtensions = func(r reflect.Value, m reflect.Method, funcDecl *ast.FuncDecl) (Extensions, error) {
+		if m.Name == "Div" {
+			return Extensions{"x-permissions": []string{"admin"}, "x-rate-limit": 10}, nil
+		}
+		return nil, nil
+	}
+	reflector.FnGetContentDescriptorExtensions = func(r reflect.Value, m reflect.Method, field *ast.Field) (Extensions, error) {
+		return Extensions{"x-field": field.Names[0].Name}, nil
+	}
+	reflector.FnGetSchemaExtensions = func(r reflect.Value, m reflect.Method, field *ast.Field, ty reflect.Type) (Extensions, error) {
+		return Extensions{"x-go-type": ty.String()}, nil
+	}
+
+	d := newDocument().WithMeta(&meta).WithReflector(reflector)
+	d.RegisterReceiver(new(fakearithmetic.CalculatorRPC))
+
+	doc, err := d.DiscoverExtended()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, err := json.Marshal(doc)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	testJSON(t, b, map[string]interface{}{
+		"x-api-id":                               "calculator",
+		"methods.2.name":                         "CalculatorRPC.Div",
+		"methods.2.x-permissions.0":              "admin",
+		"methods.2.x-rate-limit":                 float64(10),
+		"methods.2.params.0.x-field":             "arg",
+		"methods.2.params.0.schema.x-go-type":    "fakearithmetic.DivArg",
+		"methods.2.result.x-field":               "reply",
+		"methods.2.result.schema.x-go-type":      "*fakearithmetic.DivReply",
+		"methods.0.name":                         "CalculatorRPC.Add",
+		"methods.0.x-permissions":                nil,
+		"methods.0.params.0.schema.properties.a": map[string]interface{}{"type": "integer"},
+	})
+
+	// Overridden params aren't the reflector's fields to extend.
+	reflector.FnGetMethodParams = func(r reflect.Value, m reflect.Method, funcDecl *ast.FuncDecl) ([]meta_schema.ContentDescriptorObject, error) {
+		return []meta_schema.ContentDescriptorObject{}, nil
+	}
+	doc, err = d.DiscoverExtended()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, err = json.Marshal(doc)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	testJSON(t, b, map[string]interface{}{
+		"methods.2.params.#":       float64(0),
+		"methods.2.result.x-field": "reply",
+	})
+}
+
+func TestExtendedDocument_MarshalJSON(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(EthereumReflector)
+	doc, err := d.DiscoverExtended()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	doc.Extend("", Extensions{"x-version": 1})
+	b, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	testJSON(t, b, map[string]interface{}{`x-version`: float64(1)})
+
+	doc.Extend("/info", Extensions{"version": 1})
+	_, err = json.Marshal(doc)
+	assert.Error(t, err)
+
+	doc.Extensions = nil
+	doc.Extend("/methods/7", Extensions{"x-nope": 1})
+	_, err = json.Marshal(doc)
+	assert.Error(t, err)
+}
@@ -0,0 +1,196 @@
+package go_openrpc_reflect
+
+import (
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// argField is a JSON field of a flattened struct arg.
+type argField struct {
+	// index is the field's reflect index sequence, through any embedded structs.
+	index []int
+	ty    reflect.Type
+	// field is a synthesized AST field, named by the field's JSON name, which carries
+	// the struct field's docs, type expression and tag, if its source was found.
+	field *ast.Field
+}
+
+// isFlattenableArg tells whether an arg type is a struct, or a pointer to one,
+// which can be flattened into by-name params.
+func isFlattenableArg(ty reflect.Type) bool {
+	if ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+	return ty.Kind() == reflect.Struct
+}
+
+// flattenedArgFields returns the fields of a struct arg as encoding/json sees them:
+// exported fields not tagged "-", named by their json tags, with the fields of
+// untagged embedded structs promoted.
+// The method is used to locate the source of the struct's declaration, for field docs.
+func flattenedArgFields(m reflect.Method, ty reflect.Type) []argField {
+	if ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+	fields := []argField{}
+	seen := map[string]int{}
+	var walk func(ty reflect.Type, index []int)
+	walk = func(ty reflect.Type, index []int) {
+		decls := astStructFields(m, ty)
+		for i := 0; i < ty.NumField(); i++ {
+			sf := ty.Field(i)
+			tag := sf.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name := strings.Split(tag, ",")[0]
+			idx := append(append([]int{}, index...), i)
+
+			if sf.Anonymous && name == "" {
+				et := sf.Type
+				if et.Kind() == reflect.Ptr {
+					et = et.Elem()
+				}
+				if et.Kind() == reflect.Struct {
+					walk(et, idx)
+					continue
+				}
+			}
+			if sf.PkgPath != "" {
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+
+			f := &ast.Field{
+				Names: []*ast.Ident{ast.NewIdent(name)},
+				Type:  ast.NewIdent(sf.Type.String()),
+			}
+			if sf.Tag != "" {
+				f.Tag = &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(string(sf.Tag))}
+			}
+			if decl, ok := decls[sf.Name]; ok {
+				f.Doc, f.Comment, f.Type = decl.Doc, decl.Comment, decl.Type
+			}
+
+			// As with encoding/json, the shallowest of fields sharing a name wins.
+			if j, ok := seen[name]; ok {
+				if len(fields[j].index) > len(idx) {
+					fields[j] = argField{index: idx, ty: sf.Type, field: f}
+				}
+				continue
+			}
+			seen[name] = len(fields)
+			fields = append(fields, argField{index: idx, ty: sf.Type, field: f})
+		}
+	}
+	walk(ty, nil)
+	return fields
+}
+
+// isRequiredByTag tells whether a field is required by its tags:
+// fields tagged jsonschema:"required" are, and otherwise fields tagged json:",omitempty" aren't.
+func isRequiredByTag(field *ast.Field) bool {
+	if field.Tag == nil {
+		return true
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return true
+	}
+	st := reflect.StructTag(tag)
+	for _, opt := range strings.Split(st.Get("jsonschema"), ",") {
+		if opt == "required" {
+			return true
+		}
+	}
+	for _, opt := range strings.Split(st.Get("json"), ",")[1:] {
+		if opt == "omitempty" {
+			return false
+		}
+	}
+	return true
+}
+
+// flattenedDescriptorFields returns the field and type of each field of a struct arg.
+func flattenedDescriptorFields(m reflect.Method, ty reflect.Type) []descriptorField {
+	fields := flattenedArgFields(m, ty)
+	out := make([]descriptorField, len(fields))
+	for i, f := range fields {
+		out[i] = descriptorField{f.field, f.ty}
+	}
+	return out
+}
+
+// flattenedParamTypes returns the types of the fields of a struct arg.
+func flattenedParamTypes(m reflect.Method, ty reflect.Type) []reflect.Type {
+	fields := flattenedArgFields(m, ty)
+	out := make([]reflect.Type, len(fields))
+	for i, f := range fields {
+		out[i] = f.ty
+	}
+	return out
+}
+
+// assembleArg builds a struct arg of type ty from the values of its flattened fields.
+func assembleArg(m reflect.Method, ty reflect.Type, params []reflect.Value) reflect.Value {
+	st := ty
+	if st.Kind() == reflect.Ptr {
+		st = st.Elem()
+	}
+	arg := reflect.New(st)
+	for i, f := range flattenedArgFields(m, ty) {
+		if i >= len(params) {
+			break
+		}
+		v := arg.Elem()
+		for j, x := range f.index {
+			if j > 0 && v.Kind() == reflect.Ptr {
+				// Allocate embedded struct pointers.
+				if v.IsNil() {
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+				v = v.Elem()
+			}
+			v = v.Field(x)
+		}
+		v.Set(params[i])
+	}
+	if ty.Kind() == reflect.Ptr {
+		return arg
+	}
+	return arg.Elem()
+}
+
+// astStructFields returns the AST fields of a named struct type's declaration, keyed by field name.
+// It returns an empty map if the declaration can't be found.
+func astStructFields(m reflect.Method, ty reflect.Type) map[string]*ast.Field {
+	out := map[string]*ast.Field{}
+	if ty.Name() == "" || ty.PkgPath() == "" {
+		return out
+	}
+	for _, astFile := range astPackageFiles(m, ty) {
+		for _, decl := range astFile.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok || ts.Name.Name != ty.Name() {
+					continue
+				}
+				for _, f := range expandedFieldNamesFromList(st.Fields.List) {
+					out[f.Names[0].Name] = f
+				}
+				return out
+			}
+		}
+	}
+	return out
+}
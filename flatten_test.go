@@ -0,0 +1,164 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakearithmetic"
+	meta_schema "github.com/open-rpc/meta-schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type FlattenPage struct {
+	// Limit caps the number of results.
+	Limit int `json:"limit,omitempty"`
+	// Cursor is deprecated; use Limit.
+	Cursor string `json:"cursor,omitempty" jsonschema:"required"`
+}
+
+type FlattenSearchArg struct {
+	// Query is matched against item names.
+	Query string `json:"query"`
+	FlattenPage
+	Ignored string `json:"-"`
+	Tags    []string
+	private bool
+}
+
+type flattenService struct{}
+
+// Search finds items.
+func (s *flattenService) Search(arg FlattenSearchArg, reply *[]string) error {
+	*reply = append(arg.Tags, arg.Query)
+	if arg.Limit > 0 {
+		*reply = (*reply)[:arg.Limit]
+	}
+	return nil
+}
+
+// Count counts items.
+func (s *flattenService) Count(arg string, reply *int) error {
+	*reply = len(arg)
+	return nil
+}
+
+func TestStandardReflectorT_FlattenArgs(t *testing.T) {
+	reflector := &StandardReflectorT{FlattenArgs: true}
+	methods, err := reflector.ReceiverMethods("search", &flattenService{})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	type T struct {
+		Methods []meta_schema.MethodObject `json:"methods"`
+	}
+	b, err := json.MarshalIndent(T{methods}, "", "  ")
+	assert.NoError(t, err)
+
+	t.Log(string(b))
+
+	testJSON(t, b, map[string]interface{}{
+		`methods.#(name=="search.Search").paramStructure`:       "by-name",
+		`methods.#(name=="search.Search").params.#`:             float64(4),
+		`methods.#(name=="search.Search").params.0.name`:        "query",
+		`methods.#(name=="search.Search").params.0.summary`:     "Query is matched against item names.\n",
+		`methods.#(name=="search.Search").params.0.description`: "string",
+		`methods.#(name=="search.Search").params.0.required`:    true,
+		`methods.#(name=="search.Search").params.0.schema.type`: "string",
+		`methods.#(name=="search.Search").params.1.name`:        "limit",
+		`methods.#(name=="search.Search").params.1.required`:    false,
+		`methods.#(name=="search.Search").params.1.schema.type`: "integer",
+		`methods.#(name=="search.Search").params.2.name`:        "cursor",
+		`methods.#(name=="search.Search").params.2.required`:    true,
+		`methods.#(name=="search.Search").params.2.deprecated`:  true,
+		`methods.#(name=="search.Search").params.3.name`:        "Tags",
+		`methods.#(name=="search.Search").params.3.description`: "[]string",
+		`methods.#(name=="search.Search").params.3.schema.type`: "array",
+		`methods.#(name=="search.Search").result.name`:          "reply",
+		`methods.#(name=="search.Count").paramStructure`:        "by-position",
+		`methods.#(name=="search.Count").params.#`:              float64(1),
+		`methods.#(name=="search.Count").params.0.name`:         "arg",
+		`methods.#(name=="search.Count").params.0.schema.type`:  "string",
+	})
+}
+
+func TestStandardReflectorT_FlattenArgs_Package(t *testing.T) {
+	reflector := &StandardReflectorT{FlattenArgs: true}
+	methods, err := reflector.ReceiverMethods("", &fakearithmetic.CalculatorRPC{})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	type T struct {
+		Methods []meta_schema.MethodObject `json:"methods"`
+	}
+	b, err := json.MarshalIndent(T{methods}, "", "  ")
+	assert.NoError(t, err)
+
+	testJSON(t, b, map[string]interface{}{
+		`methods.#(name=="CalculatorRPC.Add").paramStructure`:   "by-name",
+		`methods.#(name=="CalculatorRPC.Add").params.#`:         float64(2),
+		`methods.#(name=="CalculatorRPC.Add").params.0.name`:    "a",
+		`methods.#(name=="CalculatorRPC.Add").params.0.summary`: "A is the first addend.\n",
+		`methods.#(name=="CalculatorRPC.Add").params.1.name`:    "b",
+		`methods.#(name=="CalculatorRPC.Add").params.1.summary`: "B is the second addend.\n",
+		`methods.#(name=="CalculatorRPC.BigMul").params.1.name`: "B",
+	})
+}
+
+func TestStandardReflectorT_FlattenArgs_Invoke(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(&StandardReflectorT{FlattenArgs: true})
+	d.RegisterReceiverName("search", &flattenService{})
+
+	bindings, err := d.Bindings()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	byName := map[string]MethodBinding{}
+	for _, b := range bindings {
+		byName[b.Name()] = b
+	}
+
+	types, err := byName["search.Search"].ParamTypes()
+	assert.NoError(t, err)
+	assert.Equal(t, []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(0), reflect.TypeOf(""), reflect.TypeOf([]string{})}, types)
+
+	result, err := byName["search.Search"].Call([]reflect.Value{
+		reflect.ValueOf("c"), reflect.ValueOf(2), reflect.ValueOf(""), reflect.ValueOf([]string{"a", "b"}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, result)
+
+	result, err = byName["search.Count"].Call([]reflect.Value{reflect.ValueOf("abc")})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result)
+}
+
+func TestGorillaReflectorT_FlattenArgs(t *testing.T) {
+	calculator := &fakearithmetic.Calculator{}
+	calculator.Reset()
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(&GorillaReflectorT{StandardReflectorT{FlattenArgs: true}})
+	d.RegisterReceiver(&fakearithmetic.CalculatorGorilla{Calculator: calculator})
+
+	doc, err := d.Discover()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	testJSON(t, b, map[string]interface{}{
+		`methods.#(name=="CalculatorGorilla.Add").paramStructure`:          "by-name",
+		`methods.#(name=="CalculatorGorilla.Add").params.#`:                float64(2),
+		`methods.#(name=="CalculatorGorilla.Add").params.0.name`:           "a",
+		`methods.#(name=="CalculatorGorilla.HasBatteries").paramStructure`: "by-position",
+	})
+
+	bindings, err := d.Bindings()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "CalculatorGorilla.Add", bindings[0].Name())
+	_, err = bindings[0].Call([]reflect.Value{reflect.ValueOf(1), reflect.ValueOf(2)})
+	assert.NoError(t, err)
+}
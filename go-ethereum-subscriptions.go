@@ -0,0 +1,428 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// go-ethereum/rpc delivers subscriptions through a pair of methods per namespace,
+// <namespace>_subscribe and <namespace>_unsubscribe, instead of one method per subscription.
+// Subscription methods, which take a context.Context and return (*rpc.Subscription, error),
+// are named by the first param of <namespace>_subscribe, and their notifications are
+// delivered as calls to <namespace>_subscription, with params {"subscription": id, "result": payload}.
+//
+// EthereumReflectorT documents the pair of methods, tagged "subscription" so that clients know
+// subscribe opens a stream. The notifications, being sent by the server rather than called by clients,
+// aren't documented as a method: extended documents describe them, and each subscription with its params
+// and notification payload, in the x-subscription extension of the subscribe method.
+
+// notificationDirective declares the type of a subscription's notification payload
+// in its method's doc comment, eg.
+//
+//	//openrpc:notification *Header
+const notificationDirective = "//openrpc:notification "
+
+// subscriptionTag tags the methods documenting subscriptions.
+const subscriptionTag = "subscription"
+
+// subscriptionExtension is the extension marking the subscribe and unsubscribe methods.
+const subscriptionExtension = "x-subscription"
+
+// isSubscriptionType tells whether a type is *rpc.Subscription of go-ethereum's rpc package.
+// It is recognized by name, so that go-ethereum needn't be imported.
+func isSubscriptionType(ty reflect.Type) bool {
+	if ty.Kind() != reflect.Ptr {
+		return false
+	}
+	ty = ty.Elem()
+	return ty.Name() == "Subscription" && path.Base(ty.PkgPath()) == "rpc"
+}
+
+// isSubscriptionMethod tells whether a method follows go-ethereum/rpc's subscription signature:
+// a context.Context as its first argument, and (*rpc.Subscription, error) results.
+func isSubscriptionMethod(method reflect.Method) bool {
+	mtype := method.Type
+	if mtype.NumIn() < 2 || mtype.In(1) != contextType {
+		return false
+	}
+	return mtype.NumOut() == 2 && isSubscriptionType(mtype.Out(0)) && mtype.Out(1) == errType
+}
+
+// subscription describes a subscription method of a receiver.
+type subscription struct {
+	// Name is the subscription's name, the first param of <namespace>_subscribe.
+	Name       string                                `json:"name"`
+	Summary    string                                `json:"summary,omitempty"`
+	Deprecated bool                                  `json:"deprecated,omitempty"`
+	Params     []meta_schema.ContentDescriptorObject `json:"params"`
+	// Result describes the notifications' payload, if its type was declared.
+	Result *meta_schema.ContentDescriptorObject `json:"result,omitempty"`
+}
+
+// receiverNamespace returns the namespace of a receiver's methods, as GetMethodName does by default.
+func receiverNamespace(moduleName string, receiver interface{}) string {
+	if moduleName != "" {
+		return moduleName
+	}
+	ty := reflect.TypeOf(receiver)
+	if ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+	return firstToLower(ty.Name())
+}
+
+func (e *EthereumReflectorT) receiverSubscriptions(receiver interface{}) ([]subscription, error) {
+	ty := reflect.TypeOf(receiver)
+	rval := reflect.ValueOf(receiver)
+
+	var types map[string]reflect.Type
+	subs := []subscription{}
+	for i := 0; i < ty.NumMethod(); i++ {
+		method := ty.Method(i)
+		if !isExportedMethod(method) || !isSubscriptionMethod(method) {
+			continue
+		}
+		fdecl, err := getAstFuncDecl(rval, method)
+		if err != nil {
+			if err == errAutogenerated {
+				continue
+			}
+			return nil, fmt.Errorf("getAstFuncDecl error: %w, receiver: %v", err, ty.String())
+		}
+		sub := subscription{Name: firstToLower(method.Name)}
+
+		if sub.Summary, err = e.GetMethodSummary(rval, method, fdecl); err != nil {
+			return nil, err
+		}
+		if sub.Deprecated, err = e.GetMethodDeprecated(rval, method, fdecl); err != nil {
+			return nil, err
+		}
+		if sub.Params, err = e.GetMethodParams(rval, method, fdecl); err != nil {
+			return nil, err
+		}
+
+		// The payload type is registered, or declared by directive.
+		field := &ast.Field{Names: []*ast.Ident{ast.NewIdent("result")}}
+		var payloadType reflect.Type
+		if v, ok := e.SubscriptionPayloads[sub.Name]; ok {
+			payloadType = reflect.TypeOf(v)
+			field.Type = ast.NewIdent(payloadType.String())
+		} else if expr := notificationTypeExpr(fdecl); expr != "" {
+			if types == nil {
+				types = reachableTypes(ty)
+			}
+			if payloadType = resolveTypeExpr(types, expr); payloadType == nil {
+				return nil, fmt.Errorf("subscription %s: notification type %s is not used by the receiver's methods; register it with SubscriptionPayloads", sub.Name, expr)
+			}
+			field.Type = ast.NewIdent(expr)
+		}
+		if payloadType != nil {
+			cd, err := buildContentDescriptorObject(e, rval, method, field, payloadType)
+			if err != nil {
+				return nil, err
+			}
+			sub.Result = &cd
+		}
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool {
+		return subs[i].Name < subs[j].Name
+	})
+	return subs, nil
+}
+
+// notificationTypeExpr returns the type expression of a notification directive
+// in the method's doc comment, if any.
+func notificationTypeExpr(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Doc == nil {
+		return ""
+	}
+	for _, c := range funcDecl.Doc.List {
+		if strings.HasPrefix(c.Text, notificationDirective) {
+			return strings.TrimSpace(strings.TrimPrefix(c.Text, notificationDirective))
+		}
+	}
+	return ""
+}
+
+// reachableTypes indexes the named types used by a receiver's methods, through their
+// signatures and struct fields, by their names as written in the receiver's package,
+// eg. Header, or rpc.ID for types of other packages.
+func reachableTypes(receiver reflect.Type) map[string]reflect.Type {
+	rt := receiver
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	out := map[string]reflect.Type{}
+	seen := map[reflect.Type]bool{}
+	var visit func(ty reflect.Type)
+	visit = func(ty reflect.Type) {
+		if seen[ty] {
+			return
+		}
+		seen[ty] = true
+		if ty.Name() != "" && ty.PkgPath() != "" {
+			name := ty.Name()
+			if ty.PkgPath() != rt.PkgPath() {
+				name = path.Base(ty.PkgPath()) + "." + name
+			}
+			out[name] = ty
+		}
+		switch ty.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			visit(ty.Elem())
+		case reflect.Map:
+			visit(ty.Key())
+			visit(ty.Elem())
+		case reflect.Struct:
+			for i := 0; i < ty.NumField(); i++ {
+				visit(ty.Field(i).Type)
+			}
+		}
+	}
+	for i := 0; i < receiver.NumMethod(); i++ {
+		mtype := receiver.Method(i).Type
+		for j := 1; j < mtype.NumIn(); j++ {
+			visit(mtype.In(j))
+		}
+		for j := 0; j < mtype.NumOut(); j++ {
+			visit(mtype.Out(j))
+		}
+	}
+	return out
+}
+
+// resolveTypeExpr resolves a type expression, a named type with any pointer and slice prefixes,
+// eg. *Header or []rpc.ID, against the index of named types.
+func resolveTypeExpr(types map[string]reflect.Type, expr string) reflect.Type {
+	switch {
+	case strings.HasPrefix(expr, "*"):
+		if ty := resolveTypeExpr(types, expr[1:]); ty != nil {
+			return reflect.PtrTo(ty)
+		}
+	case strings.HasPrefix(expr, "[]"):
+		if ty := resolveTypeExpr(types, expr[2:]); ty != nil {
+			return reflect.SliceOf(ty)
+		}
+	default:
+		return types[expr]
+	}
+	return nil
+}
+
+// methodNamespace returns the namespace of a receiver's methods.
+func (e *EthereumReflectorT) methodNamespace(name string, receiver interface{}) string {
+	return receiverNamespace(name, receiver)
+}
+
+// namespaceMethods returns the subscription methods of the receivers sharing a namespace,
+// which go-ethereum serves once for all of them.
+func (e *EthereumReflectorT) namespaceMethods(namespace string, receivers []interface{}, exts methodExtensions) ([]meta_schema.MethodObject, error) {
+	if e.FnReceiverMethods != nil {
+		return nil, nil
+	}
+	return e.subscriptionMethods(namespace, receivers, exts)
+}
+
+// subscriptionMethods returns the <namespace>_subscribe and <namespace>_unsubscribe methods
+// of the receivers' subscriptions, or none if they have none.
+// If exts isn't nil, the subscribe and unsubscribe methods' x-subscription extensions are collected in it,
+// describing the <namespace>_subscription notifications.
+func (e *EthereumReflectorT) subscriptionMethods(namespace string, receivers []interface{}, exts methodExtensions) ([]meta_schema.MethodObject, error) {
+	subs := []subscription{}
+	for _, receiver := range receivers {
+		rsubs, err := e.receiverSubscriptions(receiver)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, rsubs...)
+	}
+	if len(subs) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(subs, func(i, j int) bool {
+		return subs[i].Name < subs[j].Name
+	})
+
+	names := make([]interface{}, len(subs))
+	list := ""
+	for i, sub := range subs {
+		names[i] = sub.Name
+		list += fmt.Sprintf("\n- `%s`", sub.Name)
+		if summary := strings.TrimSpace(sub.Summary); summary != "" {
+			list += ": " + strings.ReplaceAll(summary, "\n", " ")
+		}
+	}
+
+	subscriptionName := newContentDescriptor("subscriptionName", "The name of the subscription.", true,
+		map[string]interface{}{"type": "string", "enum": names})
+	idSchema := map[string]interface{}{"type": "string", "pattern": "^0x[0-9a-f]+$"}
+	subscriptionID := newContentDescriptor("subscriptionId", "The ID of the subscription, sent with each of its notifications.", true, idSchema)
+	unsubscribed := newContentDescriptor("unsubscribed", "Whether the subscription was cancelled.", true,
+		map[string]interface{}{"type": "boolean"})
+
+	subscribe := newMethodObject(namespace+"_subscribe",
+		"Creates a subscription to notifications.",
+		fmt.Sprintf("Creates a subscription to one of the following notifications, delivered as `%s_subscription` calls:\n%s", namespace, list),
+		append([]meta_schema.ContentDescriptorObject{subscriptionName}, mergeSubscriptionParams(subs)...),
+		subscriptionID)
+	unsubscribe := newMethodObject(namespace+"_unsubscribe",
+		"Cancels a subscription.",
+		fmt.Sprintf("Cancels a subscription created with `%s_subscribe`.", namespace),
+		[]meta_schema.ContentDescriptorObject{subscriptionID},
+		unsubscribed)
+	setMethodTag(&subscribe, subscriptionTag, fmt.Sprintf("Opens a stream of notifications, delivered as `%s_subscription` calls.", namespace))
+	setMethodTag(&unsubscribe, subscriptionTag, fmt.Sprintf("Closes a stream opened with `%s_subscribe`.", namespace))
+
+	if exts != nil {
+		exts[namespace+"_subscribe"] = map[string]Extensions{"": {
+			subscriptionExtension: map[string]interface{}{
+				"unsubscribe": namespace + "_unsubscribe",
+				// The notifications are JSON-RPC notifications sent by the server, and are not answered.
+				"notification": map[string]interface{}{
+					"name":           namespace + "_subscription",
+					"paramStructure": "by-name",
+					"params": []meta_schema.ContentDescriptorObject{
+						newContentDescriptor("subscription", "The ID of the subscription.", true, idSchema),
+						notificationPayload(subs),
+					},
+				},
+				"subscriptions": subs,
+			},
+		}}
+		exts[namespace+"_unsubscribe"] = map[string]Extensions{"": {
+			subscriptionExtension: map[string]interface{}{
+				"subscribe": namespace + "_subscribe",
+			},
+		}}
+	}
+	return []meta_schema.MethodObject{subscribe, unsubscribe}, nil
+}
+
+// notificationPayload documents the payload of notifications, by any of the subscriptions' payload schemas,
+// titled by their subscriptions' names.
+// The schema of a subscription without a declared payload type allows any payload.
+func notificationPayload(subs []subscription) meta_schema.ContentDescriptorObject {
+	schemas := meta_schema.SchemaArray{}
+	for _, sub := range subs {
+		obj := meta_schema.JSONSchemaObject{}
+		if sub.Result != nil && sub.Result.Schema != nil && sub.Result.Schema.JSONSchemaObject != nil {
+			obj = *sub.Result.Schema.JSONSchemaObject
+		}
+		title := meta_schema.Title(sub.Name)
+		obj.Title = &title
+		schemas = append(schemas, meta_schema.JSONSchema{JSONSchemaObject: &obj})
+	}
+	cd := newContentDescriptor("result", "The notification's payload.", true, nil)
+	cd.Schema = &schemas[0]
+	if len(schemas) > 1 {
+		cd.Schema = &meta_schema.JSONSchema{JSONSchemaObject: &meta_schema.JSONSchemaObject{AnyOf: &schemas}}
+	}
+	return cd
+}
+
+// setMethodTag tags the method.
+func setMethodTag(method *meta_schema.MethodObject, name, description string) {
+	method.Tags = &meta_schema.MethodObjectTags{{TagObject: &meta_schema.TagObject{
+		Name:        (*meta_schema.TagObjectName)(&name),
+		Description: (*meta_schema.TagObjectDescription)(&description),
+	}}}
+}
+
+// mergeSubscriptionParams documents the params following the subscription name by position,
+// merging the params of every subscription at each position.
+// A merged param is required only if every subscription requires it.
+func mergeSubscriptionParams(subs []subscription) []meta_schema.ContentDescriptorObject {
+	out := []meta_schema.ContentDescriptorObject{}
+	for i := 0; ; i++ {
+		cds := []meta_schema.ContentDescriptorObject{}
+		required := true
+		for _, sub := range subs {
+			if i >= len(sub.Params) {
+				required = false
+				continue
+			}
+			cds = append(cds, sub.Params[i])
+			if sub.Params[i].Required == nil || !bool(*sub.Params[i].Required) {
+				required = false
+			}
+		}
+		if len(cds) == 0 {
+			return out
+		}
+		if len(cds) == 1 {
+			cd := cds[0]
+			cd.Required = (*meta_schema.ContentDescriptorObjectRequired)(&required)
+			out = append(out, cd)
+			continue
+		}
+
+		names, descriptions, schemas := []string{}, []string{}, []interface{}{}
+		seen := map[string]bool{}
+		for _, cd := range cds {
+			if n := string(*cd.Name); !seen["name:"+n] {
+				seen["name:"+n] = true
+				names = append(names, n)
+			}
+			if cd.Description != nil {
+				if d := string(*cd.Description); !seen["description:"+d] {
+					seen["description:"+d] = true
+					descriptions = append(descriptions, d)
+				}
+			}
+			b, _ := json.Marshal(cd.Schema)
+			if !seen["schema:"+string(b)] {
+				seen["schema:"+string(b)] = true
+				var s interface{}
+				json.Unmarshal(b, &s)
+				schemas = append(schemas, s)
+			}
+		}
+		var schema interface{} = map[string]interface{}{"anyOf": schemas}
+		if len(schemas) == 1 {
+			schema = schemas[0]
+		}
+		cd := newContentDescriptor(strings.Join(names, "Or"), "", required, schema)
+		description := strings.Join(descriptions, " or ")
+		cd.Description = (*meta_schema.ContentDescriptorObjectDescription)(&description)
+		out = append(out, cd)
+	}
+}
+
+func newContentDescriptor(name, summary string, required bool, schema interface{}) meta_schema.ContentDescriptorObject {
+	var s meta_schema.JSONSchema
+	b, _ := json.Marshal(schema)
+	json.Unmarshal(b, &s)
+	deprecated := false
+	return meta_schema.ContentDescriptorObject{
+		Name:       (*meta_schema.ContentDescriptorObjectName)(&name),
+		Summary:    (*meta_schema.ContentDescriptorObjectSummary)(&summary),
+		Schema:     &s,
+		Required:   (*meta_schema.ContentDescriptorObjectRequired)(&required),
+		Deprecated: (*meta_schema.ContentDescriptorObjectDeprecated)(&deprecated),
+	}
+}
+
+func newMethodObject(name, summary, description string, params []meta_schema.ContentDescriptorObject, result meta_schema.ContentDescriptorObject) meta_schema.MethodObject {
+	paramStructure, deprecated := "by-position", false
+	ps := []meta_schema.ContentDescriptorOrReference{}
+	for i := range params {
+		ps = append(ps, meta_schema.ContentDescriptorOrReference{ContentDescriptorObject: &params[i]})
+	}
+	return meta_schema.MethodObject{
+		Name:           (*meta_schema.MethodObjectName)(&name),
+		Description:    (*meta_schema.MethodObjectDescription)(&description),
+		Summary:        (*meta_schema.MethodObjectSummary)(&summary),
+		ParamStructure: (*meta_schema.MethodObjectParamStructure)(&paramStructure),
+		Params:         (*meta_schema.MethodObjectParams)(&ps),
+		Result:         &meta_schema.MethodObjectResult{ContentDescriptorObject: &result},
+		Deprecated:     (*meta_schema.MethodObjectDeprecated)(&deprecated),
+	}
+}
@@ -0,0 +1,158 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakeethereum"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEthereumReflectorT_IsMethodEligible_Subscriptions(t *testing.T) {
+	for _, m := range []string{"NewHeads", "Logs", "Syncing"} {
+		method, ok := reflect.TypeOf(&fakeethereum.Chain{}).MethodByName(m)
+		assert.True(t, ok)
+		assert.True(t, isSubscriptionMethod(method), m)
+		assert.False(t, EthereumReflector.IsMethodEligible(method), m)
+	}
+	method, _ := reflect.TypeOf(&fakeethereum.Chain{}).MethodByName("HeaderByNumber")
+	assert.False(t, isSubscriptionMethod(method))
+	assert.True(t, EthereumReflector.IsMethodEligible(method))
+}
+
+func TestEthereumReflectorT_Subscriptions(t *testing.T) {
+	reflector := &EthereumReflectorT{
+		SubscriptionPayloads: map[string]interface{}{
+			"logs": fakeethereum.Log{},
+		},
+	}
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(reflector)
+	d.RegisterReceiverName("eth", &fakeethereum.Chain{})
+
+	doc, err := d.DiscoverExtended()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	assert.NoError(t, err)
+
+	t.Log(string(b))
+
+	// The notifications, which clients can't call, are described by the subscribe method's extension only.
+	testJSON(t, b, map[string]interface{}{
+		`methods.#`:      float64(3),
+		`methods.0.name`: "eth_headerByNumber",
+		`methods.1.name`: "eth_subscribe",
+		`methods.2.name`: "eth_unsubscribe",
+		`methods.0.tags`: nil,
+
+		`methods.1.paramStructure`:         "by-position",
+		`methods.1.params.#`:               float64(2),
+		`methods.1.params.0.name`:          "subscriptionName",
+		`methods.1.params.0.required`:      true,
+		`methods.1.params.0.schema.enum.#`: float64(3),
+		`methods.1.params.0.schema.enum.1`: "newHeads",
+		`methods.1.params.1.name`:          "crit",
+		`methods.1.params.1.required`:      false,
+		`methods.1.params.1.schema.type`:   "object",
+		`methods.1.result.name`:            "subscriptionId",
+		`methods.1.result.schema.type`:     "string",
+		`methods.1.tags.#`:                 float64(1),
+		`methods.1.tags.0.name`:            "subscription",
+		`methods.1.tags.0.description`:     "Opens a stream of notifications, delivered as `eth_subscription` calls.",
+
+		`methods.1.x-subscription.notification.name`:                                           "eth_subscription",
+		`methods.1.x-subscription.notification.paramStructure`:                                 "by-name",
+		`methods.1.x-subscription.notification.params.0.name`:                                  "subscription",
+		`methods.1.x-subscription.notification.params.0.schema.type.0`:                         "string",
+		`methods.1.x-subscription.notification.params.1.name`:                                  "result",
+		`methods.1.x-subscription.notification.params.1.schema.anyOf.#`:                        float64(3),
+		`methods.1.x-subscription.notification.params.1.schema.anyOf.0.title`:                  "logs",
+		`methods.1.x-subscription.notification.params.1.schema.anyOf.0.properties.topics.type`: "array",
+		`methods.1.x-subscription.notification.params.1.schema.anyOf.1.title`:                  "newHeads",
+		`methods.1.x-subscription.notification.params.1.schema.anyOf.1.properties.number.type`: "integer",
+		`methods.1.x-subscription.notification.params.1.schema.anyOf.2.title`:                  "syncing",
+		`methods.1.x-subscription.notification.params.1.schema.anyOf.2.type`:                   nil,
+		`methods.1.x-subscription.notification.result`:                                         nil,
+
+		`methods.2.params.0.name`:      "subscriptionId",
+		`methods.2.result.schema.type`: "boolean",
+		`methods.2.tags.0.name`:        "subscription",
+	})
+
+	// Plain documents have the subscribe and unsubscribe methods only.
+	plain, err := d.Discover()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, err = json.Marshal(plain)
+	assert.NoError(t, err)
+	testJSON(t, b, map[string]interface{}{
+		`methods.#`:                           float64(3),
+		`methods.1.name`:                      "eth_subscribe",
+		`methods.1.x-subscription`:            nil,
+		`methods.#(name=="eth_subscription")`: nil,
+	})
+}
+
+func TestEthereumReflectorT_Subscriptions_Namespace(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(&EthereumReflectorT{})
+	d.RegisterReceiverName("eth", &fakeethereum.Chain{})
+	d.RegisterReceiverName("eth", &fakeethereum.Pool{})
+
+	doc, err := d.Discover()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	assert.NoError(t, err)
+
+	testJSON(t, b, map[string]interface{}{
+		`methods.#`:                        float64(4),
+		`methods.0.name`:                   "eth_headerByNumber",
+		`methods.1.name`:                   "eth_pendingCount",
+		`methods.2.name`:                   "eth_subscribe",
+		`methods.2.params.0.schema.enum.#`: float64(4),
+		`methods.2.params.0.schema.enum.2`: "newPendingTransactions",
+		`methods.3.name`:                   "eth_unsubscribe",
+	})
+}
+
+func TestEthereumReflectorT_Subscriptions_Extension(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(&EthereumReflectorT{})
+	d.RegisterReceiverName("eth", &fakeethereum.Chain{})
+	d.RegisterReceiverName("eth", &fakeethereum.Pool{})
+
+	doc, err := d.DiscoverExtended()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	assert.NoError(t, err)
+
+	testJSON(t, b, map[string]interface{}{
+		`methods.0.x-subscription`:                                      nil,
+		`methods.2.name`:                                                "eth_subscribe",
+		`methods.2.x-subscription.unsubscribe`:                          "eth_unsubscribe",
+		`methods.2.x-subscription.notification.name`:                    "eth_subscription",
+		`methods.2.x-subscription.notification.params.1.schema.anyOf.#`: float64(4),
+		`methods.2.x-subscription.subscriptions.#`:                      float64(4),
+		`methods.2.x-subscription.subscriptions.0.name`:                 "logs",
+		`methods.2.x-subscription.subscriptions.0.params.0.name`:        "crit",
+		`methods.2.x-subscription.subscriptions.1.name`:                 "newHeads",
+		`methods.2.x-subscription.subscriptions.1.result.name`:          "result",
+		`methods.2.x-subscription.subscriptions.2.name`:                 "newPendingTransactions",
+		`methods.2.x-subscription.subscriptions.2.result`:               nil,
+		`methods.3.name`:                     "eth_unsubscribe",
+		`methods.3.x-subscription.subscribe`: "eth_subscribe",
+	})
+}
+
+func TestResolveTypeExpr(t *testing.T) {
+	types := reachableTypes(reflect.TypeOf(&fakeethereum.Chain{}))
+	assert.Equal(t, reflect.TypeOf(&fakeethereum.Header{}), resolveTypeExpr(types, "*Header"))
+	assert.Equal(t, reflect.TypeOf([]fakeethereum.FilterCriteria{}), resolveTypeExpr(types, "[]FilterCriteria"))
+	assert.NotNil(t, resolveTypeExpr(types, "rpc.ID"))
+	assert.Nil(t, resolveTypeExpr(types, "Block"))
+}
@@ -0,0 +1,86 @@
+package go_openrpc_reflect
+
+import (
+	"path"
+	"reflect"
+
+	"github.com/alecthomas/jsonschema"
+)
+
+// Schemas of the Ethereum execution API specification's base types, titled by their names in it.
+var (
+	ethereumUintSchema = &jsonschema.Type{
+		Title:   "uint",
+		Type:    "string",
+		Pattern: "^0x(0|[1-9a-f][0-9a-f]*)$",
+	}
+	ethereumUint64Schema = &jsonschema.Type{
+		Title:   "uint64",
+		Type:    "string",
+		Pattern: "^0x(0|[1-9a-f][0-9a-f]{0,15})$",
+	}
+	ethereumUint256Schema = &jsonschema.Type{
+		Title:   "uint256",
+		Type:    "string",
+		Pattern: "^0x(0|[1-9a-f][0-9a-f]{0,63})$",
+	}
+	ethereumBytesSchema = &jsonschema.Type{
+		Title:   "bytes",
+		Type:    "string",
+		Pattern: "^0x[0-9a-f]*$",
+	}
+	ethereumAddressSchema = &jsonschema.Type{
+		Title:   "address",
+		Type:    "string",
+		Pattern: "^0x[0-9a-fA-F]{40}$",
+	}
+	ethereumHash32Schema = &jsonschema.Type{
+		Title:   "hash32",
+		Type:    "string",
+		Pattern: "^0x[0-9a-f]{64}$",
+	}
+	ethereumBlockTagSchema = &jsonschema.Type{
+		Title: "BlockTag",
+		Type:  "string",
+		Enum:  []interface{}{"earliest", "finalized", "safe", "latest", "pending"},
+	}
+	ethereumBlockNumberOrTagSchema = &jsonschema.Type{
+		Title: "BlockNumberOrTag",
+		OneOf: []*jsonschema.Type{ethereumUintSchema, ethereumBlockTagSchema},
+	}
+	ethereumBlockNumberOrTagOrHashSchema = &jsonschema.Type{
+		Title: "BlockNumberOrTagOrHash",
+		OneOf: []*jsonschema.Type{ethereumUintSchema, ethereumBlockTagSchema, ethereumHash32Schema},
+	}
+)
+
+// ethereumTypeSchemas maps go-ethereum types, by their package's name and their own, to their schemas.
+var ethereumTypeSchemas = map[[2]string]*jsonschema.Type{
+	{"hexutil", "Big"}:           ethereumUint256Schema,
+	{"hexutil", "Uint64"}:        ethereumUint64Schema,
+	{"hexutil", "Uint"}:          ethereumUintSchema,
+	{"hexutil", "Bytes"}:         ethereumBytesSchema,
+	{"common", "Address"}:        ethereumAddressSchema,
+	{"common", "Hash"}:           ethereumHash32Schema,
+	{"rpc", "BlockNumber"}:       ethereumBlockNumberOrTagSchema,
+	{"rpc", "BlockNumberOrHash"}: ethereumBlockNumberOrTagOrHashSchema,
+}
+
+// EthereumSchemaTypeMap maps go-ethereum's hex encoded types to the schemas of the Ethereum execution API
+// specification: hexutil.Big, hexutil.Uint64, hexutil.Uint and hexutil.Bytes, common.Address and common.Hash,
+// rpc.BlockNumber and rpc.BlockNumberOrHash.
+// Types are recognized by their package's name and their own, so that go-ethereum needn't be imported,
+// and its forks are recognized too.
+// It is the default SchemaTypeMap of the EthereumReflector, composed with StandardSchemaTypeMap.
+func EthereumSchemaTypeMap(ty reflect.Type) *jsonschema.Type {
+	if ty.Name() == "" {
+		return nil
+	}
+	schema, ok := ethereumTypeSchemas[[2]string{path.Base(ty.PkgPath()), ty.Name()}]
+	if !ok {
+		return nil
+	}
+	// The reflector may modify the schemas it's given.
+	cp := *schema
+	return &cp
+}
@@ -0,0 +1,61 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakeethereum"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEthereumReflectorT_SchemaTypeMap(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(EthereumReflector)
+	d.RegisterReceiverName("eth", &fakeethereum.State{})
+
+	doc, err := d.Discover()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	assert.NoError(t, err)
+
+	t.Log(string(b))
+
+	testJSON(t, b, map[string]interface{}{
+		`methods.#`:                                float64(4),
+		`methods.0.name`:                           "eth_blockNumber",
+		`methods.0.result.schema.title`:            "uint64",
+		`methods.0.result.schema.pattern`:          "^0x(0|[1-9a-f][0-9a-f]{0,15})$",
+		`methods.1.name`:                           "eth_getBalance",
+		`methods.1.params.0.schema.title`:          "address",
+		`methods.1.params.0.schema.type`:           "string",
+		`methods.1.params.0.schema.pattern`:        "^0x[0-9a-fA-F]{40}$",
+		`methods.1.params.1.schema.title`:          "BlockNumberOrTagOrHash",
+		`methods.1.params.1.schema.oneOf.#`:        float64(3),
+		`methods.1.params.1.schema.oneOf.2.title`:  "hash32",
+		`methods.1.result.schema.title`:            "uint256",
+		`methods.2.name`:                           "eth_getBlockTransactionCount",
+		`methods.2.params.0.schema.title`:          "hash32",
+		`methods.2.result.schema.title`:            "uint",
+		`methods.3.name`:                           "eth_getCode",
+		`methods.3.params.1.schema.title`:          "BlockNumberOrTag",
+		`methods.3.params.1.schema.oneOf.1.enum.#`: float64(5),
+		`methods.3.result.schema.title`:            "bytes",
+		`methods.3.result.schema.pattern`:          "^0x[0-9a-f]*$",
+	})
+}
+
+func TestEthereumSchemaTypeMap(t *testing.T) {
+	type Address [20]byte
+	assert.Nil(t, EthereumSchemaTypeMap(reflect.TypeOf(Address{})), "only the common package's Address")
+	assert.Nil(t, EthereumSchemaTypeMap(reflect.TypeOf([]byte{})))
+
+	// Overriding the map replaces the preset.
+	reflector := &EthereumReflectorT{}
+	reflector.FnSchemaTypeMap = func() func(ty reflect.Type) *jsonschema.Type {
+		return func(ty reflect.Type) *jsonschema.Type { return nil }
+	}
+	assert.Nil(t, reflector.SchemaTypeMap()(reflect.TypeOf(fakeethereum.State{})))
+}
@@ -2,24 +2,47 @@ package go_openrpc_reflect
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"go/ast"
 	"reflect"
 	"unicode"
 
+	"github.com/alecthomas/jsonschema"
 	meta_schema "github.com/open-rpc/meta-schema"
 )
 
 type EthereumReflectorT struct {
 	StandardReflectorT
+
+	// SubscriptionPayloads maps subscription names, eg. "newHeads", to values of the types
+	// of their notifications' payloads.
+	// A payload type can also be declared in the subscription method's doc comment with an
+	// //openrpc:notification directive, eg. "//openrpc:notification *Header",
+	// if the type is used by the receiver's methods.
+	SubscriptionPayloads map[string]interface{}
 }
 
 var EthereumReflector = &EthereumReflectorT{}
 
 func (e *EthereumReflectorT) ReceiverMethods(name string, receiver interface{}) ([]meta_schema.MethodObject, error) {
+	return e.receiverMethodsExtensions(name, receiver, nil)
+}
+
+// receiverMethodsExtensions is ReceiverMethods, collecting the methods' extensions in exts if it isn't nil.
+func (e *EthereumReflectorT) receiverMethodsExtensions(name string, receiver interface{}, exts methodExtensions) ([]meta_schema.MethodObject, error) {
 	if e.FnReceiverMethods != nil {
 		return e.FnReceiverMethods(name, receiver)
 	}
-	return receiverMethods(e, name, receiver)
+	methods, err := receiverMethods(e, name, receiver, exts)
+	if err != nil {
+		return nil, err
+	}
+	subscriptionMethods, err := e.subscriptionMethods(receiverNamespace(name, receiver), []interface{}{receiver}, exts)
+	if err != nil {
+		return nil, fmt.Errorf("subscriptions error: %w", err)
+	}
+	return append(methods, subscriptionMethods...), nil
 }
 
 var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
@@ -34,6 +57,10 @@ func (e *EthereumReflectorT) IsMethodEligible(method reflect.Method) bool {
 	if !isExportedMethod(method) {
 		return false
 	}
+	// Subscriptions are documented by the namespace's subscribe method.
+	if isSubscriptionMethod(method) {
+		return false
+	}
 
 	// All arg types are permitted.
 	// If context.Context is the first arg type, it will be skipped.
@@ -88,12 +115,16 @@ func (e *EthereumReflectorT) GetMethodParams(r reflect.Value, m reflect.Method,
 	if e.FnGetMethodParams != nil {
 		return e.FnGetMethodParams(r, m, astFunc)
 	}
+	return buildContentDescriptorObjects(e, r, m, e.methodParamFields(m, astFunc))
+}
+
+// methodParamFields returns the fields and types of a method's arguments, less a leading context.Context.
+func (e *EthereumReflectorT) methodParamFields(m reflect.Method, astFunc *ast.FuncDecl) []descriptorField {
+	out := []descriptorField{}
 	if astFunc.Type.Params == nil {
-		return []meta_schema.ContentDescriptorObject{}, nil
+		return out
 	}
 
-	out := []meta_schema.ContentDescriptorObject{}
-
 	expanded := expandedFieldNamesFromList(astFunc.Type.Params.List)
 
 	for i, field := range expanded {
@@ -104,32 +135,134 @@ func (e *EthereumReflectorT) GetMethodParams(r reflect.Value, m reflect.Method,
 		if i+1 == 1 && ty == contextType {
 			continue
 		}
-		cd, err := buildContentDescriptorObject(e, r, m, field, ty)
-		if err != nil {
-			return nil, err
-		}
-		out = append(out, cd)
+		out = append(out, descriptorField{field, ty})
 	}
-	return out, nil
+	return out
 }
 
 func (e *EthereumReflectorT) GetMethodResult(r reflect.Value, m reflect.Method, astFunc *ast.FuncDecl) (meta_schema.ContentDescriptorObject, error) {
 	if e.FnGetMethodResult != nil {
 		return e.FnGetMethodResult(r, m, astFunc)
 	}
-	if astFunc.Type.Results == nil {
+	f, ok := e.methodResultField(m, astFunc)
+	if !ok {
 		return nullContentDescriptor, nil
 	}
+	return buildContentDescriptorObject(e, r, m, f.field, f.ty)
+}
+
+// methodResultField returns the field and type of a method's first result,
+// or false if the method has none but an error, whose result is documented as null.
+func (e *EthereumReflectorT) methodResultField(m reflect.Method, astFunc *ast.FuncDecl) (descriptorField, bool) {
+	if astFunc.Type.Results == nil {
+		return descriptorField{}, false
+	}
 
 	expandedFields := expandedFieldNamesFromList(astFunc.Type.Results.List)
 
 	if len(expandedFields) == 0 {
-		return nullContentDescriptor, nil
+		return descriptorField{}, false
 	}
 
 	if m.Type.NumOut() == 0 || m.Type.Out(0) == errType {
-		return nullContentDescriptor, nil
+		return descriptorField{}, false
+	}
+
+	return descriptorField{expandedFields[0], m.Type.Out(0)}, true
+}
+
+// SchemaTypeMap defaults to EthereumSchemaTypeMap composed with StandardSchemaTypeMap.
+func (e *EthereumReflectorT) SchemaTypeMap() func(ty reflect.Type) *jsonschema.Type {
+	if e.FnSchemaTypeMap != nil {
+		return e.FnSchemaTypeMap()
+	}
+	return ComposeSchemaTypeMaps(EthereumSchemaTypeMap, StandardSchemaTypeMap)
+}
+
+func (e *EthereumReflectorT) GetContentDescriptorRequired(r reflect.Value, m reflect.Method, field *ast.Field) (bool, error) {
+	if e.FnGetContentDescriptorRequired != nil {
+		return e.FnGetContentDescriptorRequired(r, m, field)
+	}
+	optional, err := isOptionalParam(r, m, field)
+	return !optional, err
+}
+
+func (e *EthereumReflectorT) GetSchema(r reflect.Value, m reflect.Method, field *ast.Field, ty reflect.Type) (meta_schema.JSONSchema, error) {
+	if e.FnGetSchema != nil {
+		return e.FnGetSchema(r, m, field, ty)
+	}
+	schema, err := buildJSONSchemaObject(e, r, m, field, ty)
+	if err != nil {
+		return schema, err
+	}
+	// Omitted optional params are nil.
+	optional, err := isOptionalParam(r, m, field)
+	if err != nil || !optional {
+		return schema, err
+	}
+	return nullableSchema(schema)
+}
+
+// isOptionalParam tells whether the field is an optional param of the method.
+// go-ethereum/rpc lets clients omit a trailing run of pointer params, which are passed as nil.
+// Results are not params, so never optional.
+func isOptionalParam(r reflect.Value, m reflect.Method, field *ast.Field) (bool, error) {
+	if field.Type == nil || len(field.Names) == 0 {
+		return false, nil
+	}
+	funcDecl, err := getAstFuncDecl(r, m)
+	if err != nil {
+		if err == errAutogenerated {
+			return false, nil
+		}
+		return false, err
+	}
+	if funcDecl == nil || funcDecl.Type.Params == nil {
+		return false, nil
 	}
 
-	return buildContentDescriptorObject(e, r, m, expandedFields[0], m.Type.Out(0))
+	// The field belongs to another parse of the same declaration, so is matched by position.
+	params := expandedFieldNamesFromList(funcDecl.Type.Params.List)
+	for i, f := range params {
+		if f.Type.Pos() != field.Type.Pos() || f.Names[0].Name != field.Names[0].Name {
+			continue
+		}
+		for j := i; j < len(params); j++ {
+			if m.Type.In(j+1).Kind() != reflect.Ptr {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// nullableSchema returns the schema, amended to also allow null.
+func nullableSchema(schema meta_schema.JSONSchema) (meta_schema.JSONSchema, error) {
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return schema, err
+	}
+	var s interface{}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return schema, err
+	}
+	obj, ok := s.(map[string]interface{})
+	if !ok || len(obj) == 0 {
+		// Boolean and empty schemas allow anything.
+		return schema, nil
+	}
+	types, _ := obj["type"].([]interface{})
+	for _, t := range types {
+		if t == "null" {
+			return schema, nil
+		}
+	}
+	b, err = json.Marshal(map[string]interface{}{
+		"anyOf": []interface{}{obj, map[string]interface{}{"type": "null"}},
+	})
+	if err != nil {
+		return schema, err
+	}
+	return unmarshalJSONSchema(b)
 }
@@ -118,13 +118,12 @@ func TestEthereumReflectorT_ReceiverMethods(t *testing.T) {
 		`methods.#(name=="calculator_add").externalDocs.description`: regexp.MustCompile(`(?m)^Github remote link$`),
 		`methods.#(name=="calculator_add").externalDocs.url`:         regexp.MustCompile(`(?m)^http.*github.*fakearithmetic.*fakearithmetic\.go`),
 
-		`methods.#(name=="calculator_bigMul").name`:                                 "calculator_bigMul",
-		`methods.#(name=="calculator_bigMul").summary`:                              regexp.MustCompile(`returns.*the product of.*`),
-		`methods.#(name=="calculator_bigMul").params.#`:                             float64(2),
-		`methods.#(name=="calculator_bigMul").params.0.name`:                        "argA",
-		`methods.#(name=="calculator_bigMul").params.0.description`:                 "*big.Int",
-		`methods.#(name=="calculator_bigMul").params.0.schema.type`:                 "object",
-		`methods.#(name=="calculator_bigMul").params.0.schema.additionalProperties`: false,
+		`methods.#(name=="calculator_bigMul").name`:                         "calculator_bigMul",
+		`methods.#(name=="calculator_bigMul").summary`:                      regexp.MustCompile(`returns.*the product of.*`),
+		`methods.#(name=="calculator_bigMul").params.#`:                     float64(2),
+		`methods.#(name=="calculator_bigMul").params.0.name`:                "argA",
+		`methods.#(name=="calculator_bigMul").params.0.description`:         "*big.Int",
+		`methods.#(name=="calculator_bigMul").params.0.schema.anyOf.0.type`: "integer",
 
 		`methods.#(name=="calculator_div").deprecated`: true,
 
@@ -136,7 +135,7 @@ func TestEthereumReflectorT_ReceiverMethods(t *testing.T) {
 		`methods.#(name=="calculator_history").result.description`:                           "[]HistoryItem",
 		`methods.#(name=="calculator_history").result.schema.type`:                           "array",
 		`methods.#(name=="calculator_history").result.schema.items.0.type`:                   "object",
-		`methods.#(name=="calculator_history").result.schema.items.0.properties.Args.type`:   "array", 
+		`methods.#(name=="calculator_history").result.schema.items.0.properties.Args.type`:   "array",
 		`methods.#(name=="calculator_history").result.schema.items.0.properties.Method.type`: "string",
 
 		`methods.#(name=="calculator_last").params.#`:                             float64(0),
@@ -195,19 +194,47 @@ func TestEthereumReflectorT_GetMethodParams(t *testing.T) {
 			methodName: "BigMul",
 			count:      2,
 			params: map[string]interface{}{
-				"params.0.name":        "argA",
-				"params.0.description": "*big.Int",
-				"params.0.summary":     "",
-				"params.0.required":    true,
-				"params.0.deprecated":  false,
-				"params.0.schema.type": "object",
-
-				"params.1.name":        "argB",
-				"params.1.description": "*big.Int",
-				"params.1.summary":     "",
-				"params.1.required":    true,
-				"params.1.deprecated":  false,
-				"params.1.schema.type": "object",
+				"params.0.name":                "argA",
+				"params.0.description":         "*big.Int",
+				"params.0.summary":             "",
+				"params.0.required":            false,
+				"params.0.deprecated":          false,
+				"params.0.schema.anyOf.0.type": "integer",
+				"params.0.schema.anyOf.1.type": "null",
+
+				"params.1.name":                "argB",
+				"params.1.description":         "*big.Int",
+				"params.1.summary":             "",
+				"params.1.required":            false,
+				"params.1.deprecated":          false,
+				"params.1.schema.anyOf.0.type": "integer",
+				"params.1.schema.anyOf.1.type": "null",
+			},
+		},
+		{
+			// Show that only the trailing run of pointer params is optional.
+			service:    &fakearithmetic.Rounder{},
+			methodName: "Round",
+			count:      2,
+			params: map[string]interface{}{
+				"params.0.name":                "number",
+				"params.0.required":            true,
+				"params.0.schema.type":         "number",
+				"params.0.schema.anyOf":        nil,
+				"params.1.name":                "places",
+				"params.1.required":            false,
+				"params.1.schema.anyOf.0.type": "integer",
+				"params.1.schema.anyOf.1.type": "null",
+			},
+		},
+		{
+			// Show that a trailing run of several pointer params is optional.
+			service:    &fakearithmetic.Calculator{},
+			methodName: "GuessAreaOfCircle",
+			count:      2,
+			params: map[string]interface{}{
+				"params.0.required": false,
+				"params.1.required": false,
 			},
 		},
 		{
@@ -0,0 +1,153 @@
+package go_openrpc_reflect
+
+import (
+	"context"
+	"go/ast"
+	"net/http"
+	"reflect"
+
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// GorillaReflectorT documents services following the github.com/gorilla/rpc method convention:
+//
+//	func (s *T) Method(r *http.Request, args *Args, reply *Reply) error
+//
+// Methods are named Service.Method, like StandardReflectorT's, and the *http.Request,
+// which is supplied by the server, is not documented as a param.
+// The args are documented as the only (by-position) param, as gorilla's json codec expects,
+// or as by-name params with FlattenArgs set, as its json2 codec expects.
+type GorillaReflectorT struct {
+	StandardReflectorT
+}
+
+var GorillaReflector = &GorillaReflectorT{}
+
+var httpRequestType = reflect.TypeOf((*http.Request)(nil))
+
+func (g *GorillaReflectorT) ReceiverMethods(name string, receiver interface{}) ([]meta_schema.MethodObject, error) {
+	return g.receiverMethodsExtensions(name, receiver, nil)
+}
+
+// receiverMethodsExtensions is ReceiverMethods, collecting the methods' extensions in exts if it isn't nil.
+func (g *GorillaReflectorT) receiverMethodsExtensions(name string, receiver interface{}, exts methodExtensions) ([]meta_schema.MethodObject, error) {
+	if g.FnReceiverMethods != nil {
+		return g.FnReceiverMethods(name, receiver)
+	}
+	return receiverMethods(g, name, receiver, exts)
+}
+
+// ------------------------------------------------------------------------------
+
+func (g *GorillaReflectorT) IsMethodEligible(method reflect.Method) bool {
+	if g.FnIsMethodEligible != nil {
+		return g.FnIsMethodEligible(method)
+	}
+	// Method must be exported.
+	if !isExportedMethod(method) {
+		return false
+	}
+
+	mtype := method.Type
+
+	// Method needs four ins: receiver, *http.Request, *args, *reply.
+	if mtype.NumIn() != 4 {
+		return false
+	}
+	// First arg must be *http.Request.
+	if mtype.In(1) != httpRequestType {
+		return false
+	}
+	// Second arg must be a pointer and must be exported.
+	argsType := mtype.In(2)
+	if argsType.Kind() != reflect.Ptr || !isExportedOrBuiltinType(argsType) {
+		return false
+	}
+	// Third arg must be a pointer and must be exported.
+	replyType := mtype.In(3)
+	if replyType.Kind() != reflect.Ptr || !isExportedOrBuiltinType(replyType) {
+		return false
+	}
+	// Method needs one out: error.
+	if mtype.NumOut() != 1 || mtype.Out(0) != errType {
+		return false
+	}
+	return true
+}
+
+func (g *GorillaReflectorT) GetMethodParams(r reflect.Value, m reflect.Method, funcDecl *ast.FuncDecl) ([]meta_schema.ContentDescriptorObject, error) {
+	if g.FnGetMethodParams != nil {
+		return g.FnGetMethodParams(r, m, funcDecl)
+	}
+	return buildContentDescriptorObjects(g, r, m, g.methodParamFields(m, funcDecl))
+}
+
+// methodParamFields returns the field and type of a gorilla/rpc method's args,
+// or those of their fields with FlattenArgs set.
+func (g *GorillaReflectorT) methodParamFields(m reflect.Method, funcDecl *ast.FuncDecl) []descriptorField {
+	if funcDecl.Type.Params == nil {
+		panic("unreachable")
+	}
+
+	expandedFields := expandedFieldNamesFromList(funcDecl.Type.Params.List)
+
+	// Skip the *http.Request; we want only the args.
+	if g.FlattenArgs && isFlattenableArg(m.Type.In(2)) {
+		return flattenedDescriptorFields(m, m.Type.In(2))
+	}
+	return []descriptorField{{expandedFields[1], m.Type.In(2)}}
+}
+
+func (g *GorillaReflectorT) GetMethodResult(r reflect.Value, m reflect.Method, funcDecl *ast.FuncDecl) (meta_schema.ContentDescriptorObject, error) {
+	if g.FnGetMethodResult != nil {
+		return g.FnGetMethodResult(r, m, funcDecl)
+	}
+	f, _ := g.methodResultField(m, funcDecl)
+	return buildContentDescriptorObject(g, r, m, f.field, f.ty)
+}
+
+// methodResultField returns the field and type of a gorilla/rpc method's reply.
+func (g *GorillaReflectorT) methodResultField(m reflect.Method, funcDecl *ast.FuncDecl) (descriptorField, bool) {
+	if funcDecl.Type.Params == nil {
+		panic("unreachable")
+	}
+
+	expandedFields := expandedFieldNamesFromList(funcDecl.Type.Params.List)
+
+	// The reply is the last param.
+	return descriptorField{expandedFields[2], m.Type.In(3)}, true
+}
+
+func (g *GorillaReflectorT) GetMethodParamStructure(r reflect.Value, m reflect.Method, funcDecl *ast.FuncDecl) (string, error) {
+	if g.FnGetMethodParamStructure != nil {
+		return g.FnGetMethodParamStructure(r, m, funcDecl)
+	}
+	if g.FlattenArgs && isFlattenableArg(m.Type.In(2)) {
+		return "by-name", nil
+	}
+	return "by-position", nil
+}
+
+// GetMethodParamTypes returns the args type, the only documented param of gorilla/rpc methods,
+// or the types of its fields if FlattenArgs is set.
+func (g *GorillaReflectorT) GetMethodParamTypes(r reflect.Value, m reflect.Method) ([]reflect.Type, error) {
+	if g.FlattenArgs && isFlattenableArg(m.Type.In(2)) {
+		return flattenedParamTypes(m, m.Type.In(2)), nil
+	}
+	return []reflect.Type{m.Type.In(2)}, nil
+}
+
+// InvokeMethod calls a gorilla/rpc method with an empty request, returning the value of its reply.
+func (g *GorillaReflectorT) InvokeMethod(r reflect.Value, m reflect.Method, params []reflect.Value) (interface{}, error) {
+	req := (&http.Request{Method: http.MethodPost, Header: http.Header{}}).WithContext(context.Background())
+	arg := params[0]
+	if g.FlattenArgs && isFlattenableArg(m.Type.In(2)) {
+		arg = assembleArg(m, m.Type.In(2), params)
+	}
+	reply := reflect.New(m.Type.In(3).Elem())
+	out := m.Func.Call([]reflect.Value{r, reflect.ValueOf(req), arg, reply})
+	if err, _ := out[0].Interface().(error); err != nil {
+		return nil, err
+	}
+	return reply.Elem().Interface(), nil
+}
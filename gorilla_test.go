@@ -0,0 +1,119 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakearithmetic"
+	meta_schema "github.com/open-rpc/meta-schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func newGorillaMethodTester() *MethodTester {
+	return &MethodTester{
+		reflector: GorillaReflector,
+		service:   &fakearithmetic.CalculatorGorilla{},
+		methods: map[string]string{
+			"HasBatteries": "CalculatorGorilla.HasBatteries",
+			"Add":          "CalculatorGorilla.Add",
+			"Div":          "CalculatorGorilla.Div",
+		},
+		deprecated: []string{"Div"},
+		descriptionMatches: map[string]string{
+			".{1}": "(?m)^.*[a-z]+.*$",            // Non empty.
+			".{2}": `func\s+\(.*\).*http.Request`, // Contains func declaration.
+		},
+		summaryMatches: map[string]string{
+			"HasBatteries": `if the calculator has batteries`,
+			"Add":          `sums the A and B fields`,
+			"Div":          `Use Add instead`,
+		},
+		externalDocsMatches: map[string]string{
+			"Add": `(?m)^https\:\/\/.*\.com.*\/internal\/fakearithmetic\/fakearithmetic\.go\#L\d+`,
+		},
+	}
+}
+
+func TestGorillaReflector(t *testing.T) {
+	testMethodRegisterer(t, newGorillaMethodTester())
+}
+
+func TestGorillaReflectorT_IsMethodEligible(t *testing.T) {
+	for _, m := range []string{
+		"HasBatteries",
+		"Add",
+		"Div",
+	} {
+		method, ok := reflect.TypeOf(&fakearithmetic.CalculatorGorilla{}).MethodByName(m)
+		assert.True(t, ok)
+		assert.True(t, GorillaReflector.IsMethodEligible(method), method.Name)
+	}
+
+	for _, m := range []string{
+		"IsZero",
+		"Mul",
+		"Last",
+	} {
+		method, ok := reflect.TypeOf(&fakearithmetic.CalculatorGorilla{}).MethodByName(m)
+		assert.True(t, ok)
+		assert.False(t, GorillaReflector.IsMethodEligible(method), method.Name)
+	}
+}
+
+func TestGorillaReflectorT_ReceiverMethods(t *testing.T) {
+	methods, err := GorillaReflector.ReceiverMethods("", &fakearithmetic.CalculatorGorilla{})
+	if !assert.NoError(t, err) {
+		t.Fatal("gorilla methods")
+	}
+	if !assert.Len(t, methods, len(newGorillaMethodTester().methods)) {
+		t.Fatal("gorilla methods")
+	}
+
+	type T struct {
+		Methods []meta_schema.MethodObject `json:"methods"`
+	}
+	b, err := json.MarshalIndent(T{methods}, "", "  ")
+	assert.NoError(t, err)
+
+	t.Log(string(b))
+
+	jsonTests := map[string]interface{}{
+		`methods.#(name=="CalculatorGorilla.Add").paramStructure`:                    "by-position",
+		`methods.#(name=="CalculatorGorilla.Add").params.#`:                          float64(1),
+		`methods.#(name=="CalculatorGorilla.Add").params.0.name`:                     "arg",
+		`methods.#(name=="CalculatorGorilla.Add").params.0.description`:              "*AddArg",
+		`methods.#(name=="CalculatorGorilla.Add").params.0.required`:                 true,
+		`methods.#(name=="CalculatorGorilla.Add").params.0.schema.type`:              "object",
+		`methods.#(name=="CalculatorGorilla.Add").params.0.schema.properties.a.type`: "integer",
+		`methods.#(name=="CalculatorGorilla.Add").params.0.schema.properties.b.type`: "integer",
+		`methods.#(name=="CalculatorGorilla.Add").result.name`:                       "reply",
+		`methods.#(name=="CalculatorGorilla.Add").result.description`:                "*AddReply",
+		`methods.#(name=="CalculatorGorilla.Add").result.schema.type`:                "integer",
+		`methods.#(name=="CalculatorGorilla.Add").externalDocs.url`:                  regexp.MustCompile(`(?m)^http.*github.*fakearithmetic.*fakearithmetic\.go`),
+
+		`methods.#(name=="CalculatorGorilla.HasBatteries").params.0.schema.type`: "string",
+		`methods.#(name=="CalculatorGorilla.HasBatteries").result.schema.type`:   "boolean",
+
+		`methods.#(name=="CalculatorGorilla.Div").deprecated`: true,
+	}
+
+	testJSON(t, b, jsonTests)
+}
+
+func TestGorillaReflectorT_InvokeMethod(t *testing.T) {
+	calculator := &fakearithmetic.Calculator{}
+	calculator.Reset()
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(GorillaReflector)
+	d.RegisterReceiver(&fakearithmetic.CalculatorGorilla{Calculator: calculator})
+
+	bindings, err := d.Bindings()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "CalculatorGorilla.Add", bindings[0].Name())
+	result, err := bindings[0].Call([]reflect.Value{reflect.ValueOf(&fakearithmetic.AddArg{A: 1, B: 2})})
+	assert.NoError(t, err)
+	assert.Equal(t, fakearithmetic.AddReply(0), result)
+}
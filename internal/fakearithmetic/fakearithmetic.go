@@ -5,6 +5,7 @@ import (
 	"errors"
 	"math"
 	"math/big"
+	"net/http"
 
 	"github.com/etclabscore/go-openrpc-reflect/internal/fakegeometry"
 )
@@ -186,8 +187,9 @@ func (c *CalculatorRPC) HasBatteries(arg HasBatteriesArg, reply *HasBatteriesRep
 }
 
 type AddArg struct {
+	// A is the first addend.
 	A int `json:"a"`
-	B int `json:"b"`
+	B int `json:"b"` // B is the second addend.
 }
 type AddReply int
 
@@ -239,3 +241,48 @@ func (c *CalculatorRPC) IsZero(big.Int, *IsZeroArg) error {
 func (c *CalculatorRPC) BrokenReset() {
 	// Will not be eligible.
 }
+
+// CalculatorGorilla implements the gorilla/rpc method convention.
+type CalculatorGorilla struct {
+	*Calculator
+}
+
+// Add sums the A and B fields of the argument.
+func (c *CalculatorGorilla) Add(r *http.Request, arg *AddArg, reply *AddReply) error {
+	*reply = AddReply(c.Calculator.Add(arg.A, arg.B))
+	return nil
+}
+
+// Div is deprecated. Use Add instead.
+func (c *CalculatorGorilla) Div(r *http.Request, arg *DivArg, reply *DivReply) error {
+	return errors.New("disused")
+}
+
+// HasBatteries returns true if the calculator has batteries.
+func (c *CalculatorGorilla) HasBatteries(r *http.Request, arg *HasBatteriesArg, reply *HasBatteriesReply) error {
+	*reply = HasBatteriesReply(c.Calculator.HasBatteries())
+	return nil
+}
+
+// IsZero WILL NOT be eligible; the args are not a pointer.
+func (c *CalculatorGorilla) IsZero(r *http.Request, arg IsZeroArg, reply *bool) error {
+	return nil
+}
+
+// Mul WILL NOT be eligible; it does not take an *http.Request.
+func (c *CalculatorGorilla) Mul(arg *AddArg, reply *AddReply) error {
+	return nil
+}
+
+// Rounder rounds numbers. Its methods take optional params.
+type Rounder struct{}
+
+// Round rounds a number to a number of decimal places, or to an integer if places is omitted.
+func (r *Rounder) Round(number float64, places *int) float64 {
+	p := 0
+	if places != nil {
+		p = *places
+	}
+	scale := math.Pow(10, float64(p))
+	return math.Round(number*scale) / scale
+}
@@ -0,0 +1,51 @@
+package fakeenums
+
+// OrderStatus is the status of an order.
+type OrderStatus string
+
+const (
+	// StatusOpen awaits payment.
+	StatusOpen OrderStatus = "open"
+	StatusPaid OrderStatus = "paid" // Paid in full.
+
+	StatusDefault = StatusOpen
+)
+
+const StatusCancelled = OrderStatus("cancelled")
+
+// Priority is the priority of an order.
+type Priority int
+
+const (
+	PriorityLow Priority = iota + 1
+	PriorityHigh
+	PriorityUrgent = Priority(PriorityHigh * 5)
+)
+
+// Flag is a flag of an order.
+type Flag uint8
+
+const (
+	FlagA Flag = 1 << iota
+	FlagB
+	_
+	FlagD
+)
+
+// Undeclared has no constants.
+type Undeclared string
+
+// Kind has a single constant, and so isn't an enum.
+type Kind string
+
+const KindOrder Kind = "order"
+
+// Order is an order.
+type Order struct {
+	Status     OrderStatus   `json:"status"`
+	History    []OrderStatus `json:"history"`
+	Priority   *Priority     `json:"priority"`
+	Flags      Flag          `json:"flags"`
+	Undeclared Undeclared    `json:"undeclared"`
+	Kind       Kind          `json:"kind"`
+}
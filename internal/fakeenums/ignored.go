@@ -0,0 +1,6 @@
+//go:build ignore
+
+package fakeenums
+
+// StatusIgnored is excluded from builds, and so from the enum.
+const StatusIgnored OrderStatus = "ignored"
@@ -0,0 +1,9 @@
+// Package common mimics the types of github.com/ethereum/go-ethereum/common.
+// It is used exclusively for test and example cases, and is not intended for any use otherwise.
+package common
+
+// Address represents the 20 byte address of an Ethereum account.
+type Address [20]byte
+
+// Hash represents the 32 byte Keccak256 hash of arbitrary data.
+type Hash [32]byte
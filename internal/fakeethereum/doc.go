@@ -0,0 +1,3 @@
+// Package fakeethereum is used exclusively for test and example cases,
+// and is not intended for any use otherwise.
+package fakeethereum
@@ -0,0 +1,65 @@
+package fakeethereum
+
+import (
+	"context"
+	"errors"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakeethereum/rpc"
+)
+
+// Chain provides go-ethereum style methods and subscriptions.
+type Chain struct{}
+
+// Header is a block header.
+type Header struct {
+	Number uint64 `json:"number"`
+	Hash   string `json:"hash"`
+}
+
+// Log is a contract log event.
+type Log struct {
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+}
+
+// FilterCriteria selects logs.
+type FilterCriteria struct {
+	Addresses []string `json:"addresses"`
+}
+
+// HeaderByNumber returns the header of a block.
+func (c *Chain) HeaderByNumber(number uint64) (*Header, error) {
+	return &Header{Number: number}, nil
+}
+
+// NewHeads sends a notification each time a new header is appended to the chain.
+//
+//openrpc:notification *Header
+func (c *Chain) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	return nil, errors.New("notifications not supported")
+}
+
+// Logs sends a notification for each log matching the criteria.
+func (c *Chain) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error) {
+	return nil, errors.New("notifications not supported")
+}
+
+// Syncing sends a notification when the node starts or stops syncing.
+// Deprecated: poll syncing instead.
+func (c *Chain) Syncing(ctx context.Context) (*rpc.Subscription, error) {
+	return nil, errors.New("notifications not supported")
+}
+
+// Pool provides go-ethereum style transaction pool methods and subscriptions,
+// served under the same namespace as Chain.
+type Pool struct{}
+
+// PendingCount returns the number of pending transactions.
+func (p *Pool) PendingCount() (int, error) {
+	return 0, nil
+}
+
+// NewPendingTransactions sends a notification with the hash of each transaction entering the pool.
+func (p *Pool) NewPendingTransactions(ctx context.Context) (*rpc.Subscription, error) {
+	return nil, errors.New("notifications not supported")
+}
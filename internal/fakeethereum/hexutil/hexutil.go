@@ -0,0 +1,17 @@
+// Package hexutil mimics the types of github.com/ethereum/go-ethereum/common/hexutil.
+// It is used exclusively for test and example cases, and is not intended for any use otherwise.
+package hexutil
+
+import "math/big"
+
+// Big marshals/unmarshals as a JSON string with 0x prefix.
+type Big big.Int
+
+// Uint64 marshals/unmarshals as a JSON string with 0x prefix.
+type Uint64 uint64
+
+// Uint marshals/unmarshals as a JSON string with 0x prefix.
+type Uint uint
+
+// Bytes marshals/unmarshals as a JSON string with 0x prefix.
+type Bytes []byte
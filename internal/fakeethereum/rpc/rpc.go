@@ -0,0 +1,21 @@
+// Package rpc mimics the subscription types of github.com/ethereum/go-ethereum/rpc.
+// It is used exclusively for test and example cases, and is not intended for any use otherwise.
+package rpc
+
+// ID defines a pseudo random number that is used to identify RPC subscriptions.
+type ID string
+
+// Subscription is created by a notifier and tied to that notifier.
+type Subscription struct {
+	ID ID
+}
+
+// BlockNumber is a block number, or one of the special blocks, eg. latest, by negative numbers.
+type BlockNumber int64
+
+// BlockNumberOrHash selects a block by number or by hash.
+type BlockNumberOrHash struct {
+	BlockNumber      *BlockNumber `json:"blockNumber,omitempty"`
+	BlockHash        *[32]byte    `json:"blockHash,omitempty"`
+	RequireCanonical bool         `json:"requireCanonical,omitempty"`
+}
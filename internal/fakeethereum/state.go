@@ -0,0 +1,30 @@
+package fakeethereum
+
+import (
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakeethereum/common"
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakeethereum/hexutil"
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakeethereum/rpc"
+)
+
+// State provides go-ethereum style methods using go-ethereum's hex encoded types.
+type State struct{}
+
+// BlockNumber returns the number of the latest block.
+func (s *State) BlockNumber() (hexutil.Uint64, error) {
+	return 0, nil
+}
+
+// GetBalance returns the balance of an account at a block.
+func (s *State) GetBalance(address common.Address, block rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	return new(hexutil.Big), nil
+}
+
+// GetCode returns the code of a contract at a block.
+func (s *State) GetCode(address common.Address, block rpc.BlockNumber) (hexutil.Bytes, error) {
+	return nil, nil
+}
+
+// GetBlockTransactionCount returns the number of transactions in a block.
+func (s *State) GetBlockTransactionCount(hash common.Hash) (*hexutil.Uint, error) {
+	return nil, nil
+}
@@ -0,0 +1,191 @@
+// Package schemajson provides helpers for inspecting JSON schemas in their generic
+// (unmarshaled-to-interface) form.
+//
+// The generated meta_schema types only type the root object of a schema;
+// nested properties and definitions are left as generic JSON values.
+// Working on the generic form throughout keeps that asymmetry out of the callers.
+package schemajson
+
+import (
+	"encoding/json"
+	"sort"
+
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// Schema is a JSON schema object in its generic form.
+type Schema = map[string]interface{}
+
+// FromJSONSchema converts a generated meta_schema.JSONSchema to its generic form.
+// A nil or boolean schema yields a nil Schema, which accepts any value.
+func FromJSONSchema(s *meta_schema.JSONSchema) (Schema, error) {
+	if s == nil || s.JSONSchemaObject == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	out := Schema{}
+	err = json.Unmarshal(b, &out)
+	return out, err
+}
+
+// FromValue converts a generic JSON value (eg. a nested property) to a Schema.
+// Values which are not JSON objects yield nil.
+func FromValue(v interface{}) Schema {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return m
+}
+
+// Types returns the sorted list of simple types the schema declares.
+// The generated meta_schema.Type always marshals as an array,
+// so both the string and array forms are handled.
+func Types(s Schema) []string {
+	out := []string{}
+	switch t := s["type"].(type) {
+	case string:
+		out = append(out, t)
+	case []interface{}:
+		for _, v := range t {
+			switch vv := v.(type) {
+			case string:
+				out = append(out, vv)
+			case []interface{}:
+				// Nested arrays are an artifact of round-tripping meta_schema.Type.
+				for _, vvv := range vv {
+					if str, ok := vvv.(string); ok {
+						out = append(out, str)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(out)
+	// Round-tripping may also repeat types; drop the duplicates.
+	uniq := out[:0]
+	for i, t := range out {
+		if i == 0 || t != out[i-1] {
+			uniq = append(uniq, t)
+		}
+	}
+	return uniq
+}
+
+// HasType tells if the schema declares the given simple type.
+func HasType(s Schema, ty string) bool {
+	for _, t := range Types(s) {
+		if t == ty {
+			return true
+		}
+	}
+	return false
+}
+
+// NonNullTypes returns the declared types, less "null".
+func NonNullTypes(s Schema) []string {
+	out := []string{}
+	for _, t := range Types(s) {
+		if t != "null" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// NullableOf returns the schema made nullable by s, if s is anyOf (or oneOf) it and the null schema,
+// as reflectors write nullable schemas since meta_schema types can't hold more than one type.
+func NullableOf(s Schema) (Schema, bool) {
+	for _, key := range []string{"anyOf", "oneOf"} {
+		subs, ok := s[key].([]interface{})
+		if !ok || len(subs) != 2 {
+			continue
+		}
+		for i, sub := range subs {
+			types := Types(FromValue(sub))
+			if len(types) == 1 && types[0] == "null" {
+				return FromValue(subs[1-i]), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Items returns the schema for the items of an array schema.
+// The generated meta_schema.Items always marshals as an array,
+// so a single-element array is treated as the items schema.
+func Items(s Schema) Schema {
+	switch it := s["items"].(type) {
+	case map[string]interface{}:
+		return it
+	case []interface{}:
+		if len(it) == 1 {
+			if inner, ok := it[0].([]interface{}); ok && len(inner) == 1 {
+				return FromValue(inner[0])
+			}
+			return FromValue(it[0])
+		}
+	}
+	return nil
+}
+
+// Properties returns the object properties of the schema.
+func Properties(s Schema) map[string]Schema {
+	props, ok := s["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	out := make(map[string]Schema, len(props))
+	for k, v := range props {
+		out[k] = FromValue(v)
+	}
+	return out
+}
+
+// PropertyNames returns the sorted property names of the schema.
+func PropertyNames(s Schema) []string {
+	props := Properties(s)
+	out := make([]string, 0, len(props))
+	for k := range props {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Required returns the sorted list of required property names.
+func Required(s Schema) []string {
+	out := []string{}
+	req, ok := s["required"].([]interface{})
+	if !ok {
+		return out
+	}
+	for _, r := range req {
+		if str, ok := r.(string); ok {
+			out = append(out, str)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Enum returns the enum values of the schema, or nil if none are defined.
+func Enum(s Schema) []interface{} {
+	e, _ := s["enum"].([]interface{})
+	return e
+}
+
+// String returns the string value of the named keyword, or "" if it is not a string.
+func String(s Schema, keyword string) string {
+	str, _ := s[keyword].(string)
+	return str
+}
+
+// Number returns the numeric value of the named keyword, and whether it is set.
+func Number(s Schema, keyword string) (float64, bool) {
+	f, ok := s[keyword].(float64)
+	return f, ok
+}
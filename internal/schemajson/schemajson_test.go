@@ -0,0 +1,90 @@
+package schemajson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustSchema(t *testing.T, s string) Schema {
+	t.Helper()
+	out := Schema{}
+	if err := json.Unmarshal([]byte(s), &out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestValidate(t *testing.T) {
+	resolve := ComponentsResolver(map[string]interface{}{
+		"Small": map[string]interface{}{"type": "integer", "maximum": 9.0},
+	})
+	cases := []struct {
+		schema string
+		value  interface{}
+		expect []string
+	}{
+		{`{"type": ["integer", "null"]}`, nil, nil},
+		{`{"type": "integer"}`, 1.5, []string{"expected integer, got number"}},
+		{`{"$ref": "#/components/schemas/Small"}`, 10.0, []string{"value 10 is greater than the maximum 9"}},
+		{`{"type": "string", "pattern": "^0x[0-9a-f]+$"}`, "0xzz", []string{`value "0xzz" does not match the pattern "^0x[0-9a-f]+$"`}},
+		{`{"type": "array", "items": {"type": "string"}, "maxItems": 1}`, []interface{}{"a", 1.0}, []string{
+			"2 items is greater than the maximum 1",
+			"[1]: expected string, got integer",
+		}},
+		{`{"oneOf": [{"type": "string"}, {"type": "integer"}]}`, true, []string{"value matches 0 of the oneOf schemas, want exactly 1"}},
+		{`{"type": "object", "required": ["a"], "properties": {"a": {"type": "object", "properties": {"b": {"enum": [1]}}}}}`,
+			map[string]interface{}{"a": map[string]interface{}{"b": 2.0}}, []string{"a.b: value must be one of [1]"}},
+		{`{"type": "object", "additionalProperties": false, "properties": {"a": {}}, "patternProperties": {"^x-": {"type": "string"}}}`,
+			map[string]interface{}{"a": 1.0, "x-b": 2.0, "c": 3.0}, []string{"c: unknown property", "x-b: expected string, got integer"}},
+	}
+	for _, c := range cases {
+		errs := Validate(mustSchema(t, c.schema), c.value, resolve)
+		got := []string{}
+		for _, e := range errs {
+			got = append(got, e.Error())
+		}
+		if c.expect == nil {
+			c.expect = []string{}
+		}
+		assert.Equal(t, c.expect, got, c.schema)
+	}
+}
+
+func TestValidate_Pointer(t *testing.T) {
+	resolve := DefinitionsResolver(map[string]interface{}{
+		"item": map[string]interface{}{"type": "object", "required": []interface{}{"a/b"}},
+	})
+	s := mustSchema(t, `{"type": "object", "properties": {"items": {"type": "array", "items": {"$ref": "#/definitions/item"}}}}`)
+	v := map[string]interface{}{"items": []interface{}{map[string]interface{}{"a/b": 1.0}, map[string]interface{}{"a/b": "x", "c": 1.0}, 1.0}}
+	errs := Validate(s, v, resolve)
+	if assert.Len(t, errs, 1) {
+		e := errs[0].(*ValidationError)
+		assert.Equal(t, "items[2]", e.Path)
+		assert.Equal(t, "/items/2", e.Pointer)
+	}
+
+	errs = Validate(mustSchema(t, `{"properties": {"a/b~c": {"type": "string"}}}`), map[string]interface{}{"a/b~c": 1.0}, nil)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "/a~1b~0c", errs[0].(*ValidationError).Pointer)
+	}
+}
+
+func TestSynthesize(t *testing.T) {
+	cases := []string{
+		`{"type": "integer", "exclusiveMinimum": 3, "multipleOf": 5}`,
+		`{"type": ["null", "string"], "minLength": 3}`,
+		`{"type": "string", "format": "uri"}`,
+		`{"type": "array", "minItems": 2, "items": {"type": "boolean"}}`,
+		`{"type": "object", "required": ["a"], "properties": {"a": {"enum": ["x", "y"]}, "b": {"type": "number", "minimum": -1}}}`,
+		`{"anyOf": [{"type": "integer", "minimum": 7}, {"type": "string"}]}`,
+	}
+	for _, c := range cases {
+		s := mustSchema(t, c)
+		v := Synthesize(s, nil)
+		assert.Empty(t, Validate(s, v, nil), "%s: %v", c, v)
+	}
+	assert.Equal(t, 5.0, Synthesize(mustSchema(t, cases[0]), nil))
+	assert.Equal(t, "aaa", Synthesize(mustSchema(t, cases[1]), nil))
+}
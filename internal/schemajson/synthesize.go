@@ -0,0 +1,147 @@
+package schemajson
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Synthesize returns a generic JSON value satisfying the schema, as far as the keywords
+// supported by Validate go. Values are deterministic: the schema's default, first example,
+// const or first enum value if it has one, otherwise the smallest value of its first
+// non-null type, with object properties and the minimum number of array items filled in.
+func Synthesize(s Schema, resolve Resolver) interface{} {
+	vd := &validator{resolve: resolve}
+	return vd.synthesize(s, 0)
+}
+
+func (vd *validator) synthesize(s Schema, depth int) interface{} {
+	if s == nil || depth > maxDepth {
+		return nil
+	}
+	s = vd.deref(s)
+
+	if v, ok := s["default"]; ok {
+		return v
+	}
+	if ex, ok := s["examples"].([]interface{}); ok && len(ex) > 0 {
+		return ex[0]
+	}
+	if v, ok := s["const"]; ok {
+		return v
+	}
+	if enum := Enum(s); len(enum) > 0 {
+		return enum[0]
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		// The first subschema is good enough for anyOf and oneOf;
+		// allOf is satisfied as far as its first subschema is representative.
+		if subs := schemaList(s[key]); len(subs) > 0 {
+			return vd.synthesize(subs[0], depth+1)
+		}
+	}
+
+	types := NonNullTypes(s)
+	if len(types) == 0 {
+		switch {
+		case s["properties"] != nil:
+			types = []string{"object"}
+		case s["items"] != nil:
+			types = []string{"array"}
+		default:
+			// Null, or any value.
+			return nil
+		}
+	}
+
+	switch types[0] {
+	case "boolean":
+		return false
+	case "integer":
+		return synthesizeNumber(s, true)
+	case "number":
+		return synthesizeNumber(s, false)
+	case "string":
+		return synthesizeString(s)
+	case "array":
+		n := 0
+		if min, ok := Number(s, "minItems"); ok {
+			n = int(min)
+		}
+		out := make([]interface{}, n)
+		items := Items(s)
+		for i := range out {
+			out[i] = vd.synthesize(items, depth+1)
+		}
+		return out
+	case "object":
+		out := map[string]interface{}{}
+		props := Properties(s)
+		for _, name := range PropertyNames(s) {
+			out[name] = vd.synthesize(props[name], depth+1)
+		}
+		// Required properties without a schema still need a value.
+		for _, r := range Required(s) {
+			if _, ok := out[r]; !ok {
+				out[r] = nil
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func synthesizeNumber(s Schema, integer bool) float64 {
+	f := 0.0
+	if min, ok := Number(s, "minimum"); ok {
+		f = min
+	} else if max, ok := Number(s, "maximum"); ok && max < 0 {
+		f = max
+	}
+	if min, ok := Number(s, "exclusiveMinimum"); ok && f <= min {
+		f = min + 1
+	}
+	if max, ok := Number(s, "exclusiveMaximum"); ok && f >= max {
+		f = max - 1
+	}
+	if m, ok := Number(s, "multipleOf"); ok && m > 0 {
+		f = math.Ceil(f/m) * m
+	}
+	if integer {
+		f = math.Ceil(f)
+	}
+	return f
+}
+
+// formatExamples are example values for the common string formats.
+var formatExamples = map[string]string{
+	"date-time": "1970-01-01T00:00:00Z",
+	"date":      "1970-01-01",
+	"time":      "00:00:00Z",
+	"email":     "user@example.com",
+	"hostname":  "example.com",
+	"ipv4":      "127.0.0.1",
+	"ipv6":      "::1",
+	"uri":       "https://example.com",
+	"uuid":      "00000000-0000-0000-0000-000000000000",
+}
+
+func synthesizeString(s Schema) string {
+	if ex, ok := formatExamples[String(s, "format")]; ok {
+		return ex
+	}
+	str := ""
+	if min, ok := Number(s, "minLength"); ok && min > 0 {
+		str = strings.Repeat("a", int(min))
+	}
+	return str
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
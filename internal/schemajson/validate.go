@@ -0,0 +1,383 @@
+package schemajson
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Resolver resolves a "$ref" value to the schema it references,
+// returning nil if the reference can't be resolved.
+type Resolver func(ref string) Schema
+
+// ComponentsResolver returns a Resolver for "#/components/schemas/" references
+// into the given schema components (eg. an OpenRPC document's components.schemas).
+func ComponentsResolver(schemas map[string]interface{}) Resolver {
+	const prefix = "#/components/schemas/"
+	return func(ref string) Schema {
+		if !strings.HasPrefix(ref, prefix) {
+			return nil
+		}
+		return FromValue(schemas[strings.TrimPrefix(ref, prefix)])
+	}
+}
+
+// DefinitionsResolver returns a Resolver for "#/definitions/" references
+// into the given definitions (eg. a JSON schema's definitions).
+func DefinitionsResolver(definitions map[string]interface{}) Resolver {
+	const prefix = "#/definitions/"
+	return func(ref string) Schema {
+		if !strings.HasPrefix(ref, prefix) {
+			return nil
+		}
+		return FromValue(definitions[strings.TrimPrefix(ref, prefix)])
+	}
+}
+
+// maxDepth guards against recursive schemas.
+const maxDepth = 32
+
+// ValidationError describes a value not satisfying a schema.
+type ValidationError struct {
+	// Path locates the invalid value, eg. "items[2].name".
+	// It is empty for the value itself.
+	Path string
+	// Pointer locates the invalid value by JSON pointer, eg. "/items/2/name".
+	Pointer string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Validate validates a generic JSON value (as decoded by encoding/json) against the schema,
+// returning every error found.
+//
+// The commonly used keywords are supported: type, enum, const, properties, required,
+// patternProperties, additionalProperties, items, allOf, anyOf, oneOf, not, minimum, maximum (and their
+// exclusive forms), multipleOf, minLength, maxLength, pattern, minItems, maxItems and uniqueItems.
+// Others are ignored.
+func Validate(s Schema, v interface{}, resolve Resolver) []error {
+	vd := &validator{resolve: resolve}
+	vd.validate(location{}, s, v, 0)
+	return vd.errs
+}
+
+// location locates a value, by path and by JSON pointer.
+type location struct {
+	path, pointer string
+}
+
+func (l location) key(k string) location {
+	return location{joinPath(l.path, k), l.pointer + "/" + pointerEscaper.Replace(k)}
+}
+
+func (l location) index(i int) location {
+	return location{fmt.Sprintf("%s[%d]", l.path, i), fmt.Sprintf("%s/%d", l.pointer, i)}
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+type validator struct {
+	resolve Resolver
+	errs    []error
+}
+
+func (vd *validator) errorf(loc location, format string, args ...interface{}) {
+	vd.errs = append(vd.errs, &ValidationError{Path: loc.path, Pointer: loc.pointer, Message: fmt.Sprintf(format, args...)})
+}
+
+// valid tells if the value satisfies the schema, without recording errors.
+func (vd *validator) valid(s Schema, v interface{}, depth int) bool {
+	sub := &validator{resolve: vd.resolve}
+	sub.validate(location{}, s, v, depth)
+	return len(sub.errs) == 0
+}
+
+func (vd *validator) deref(s Schema) Schema {
+	for i := 0; i < maxDepth && s != nil; i++ {
+		ref := String(s, "$ref")
+		if ref == "" || vd.resolve == nil {
+			return s
+		}
+		r := vd.resolve(ref)
+		if r == nil {
+			return s
+		}
+		s = r
+	}
+	return s
+}
+
+func (vd *validator) validate(path location, s Schema, v interface{}, depth int) {
+	if s == nil || depth > maxDepth {
+		return
+	}
+	s = vd.deref(s)
+
+	if types := Types(s); len(types) > 0 {
+		ok := false
+		for _, t := range types {
+			if IsType(v, t) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			vd.errorf(path, "expected %s, got %s", strings.Join(types, " or "), TypeOf(v))
+			// Further keywords would only repeat the mismatch.
+			return
+		}
+	}
+
+	if enum := Enum(s); enum != nil {
+		ok := false
+		for _, e := range enum {
+			if reflect.DeepEqual(e, v) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			vd.errorf(path, "value must be one of %v", enum)
+		}
+	}
+	if c, ok := s["const"]; ok && !reflect.DeepEqual(c, v) {
+		vd.errorf(path, "value must be %v", c)
+	}
+
+	for _, sub := range schemaList(s["allOf"]) {
+		vd.validate(path, sub, v, depth+1)
+	}
+	if anyOf := schemaList(s["anyOf"]); len(anyOf) > 0 {
+		ok := false
+		for _, sub := range anyOf {
+			if vd.valid(sub, v, depth+1) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			vd.errorf(path, "value does not match any of the anyOf schemas")
+		}
+	}
+	if oneOf := schemaList(s["oneOf"]); len(oneOf) > 0 {
+		n := 0
+		for _, sub := range oneOf {
+			if vd.valid(sub, v, depth+1) {
+				n++
+			}
+		}
+		if n != 1 {
+			vd.errorf(path, "value matches %d of the oneOf schemas, want exactly 1", n)
+		}
+	}
+	if not := FromValue(s["not"]); not != nil && vd.valid(not, v, depth+1) {
+		vd.errorf(path, "value must not match the \"not\" schema")
+	}
+
+	switch vv := v.(type) {
+	case float64:
+		vd.number(path, s, vv)
+	case string:
+		vd.string(path, s, vv)
+	case []interface{}:
+		vd.array(path, s, vv, depth)
+	case map[string]interface{}:
+		vd.object(path, s, vv, depth)
+	}
+}
+
+func (vd *validator) number(path location, s Schema, f float64) {
+	if min, ok := Number(s, "minimum"); ok && f < min {
+		vd.errorf(path, "value %v is less than the minimum %v", f, min)
+	}
+	if max, ok := Number(s, "maximum"); ok && f > max {
+		vd.errorf(path, "value %v is greater than the maximum %v", f, max)
+	}
+	if min, ok := Number(s, "exclusiveMinimum"); ok && f <= min {
+		vd.errorf(path, "value %v must be greater than %v", f, min)
+	}
+	if max, ok := Number(s, "exclusiveMaximum"); ok && f >= max {
+		vd.errorf(path, "value %v must be less than %v", f, max)
+	}
+	if m, ok := Number(s, "multipleOf"); ok && m > 0 {
+		if q := f / m; q != math.Trunc(q) {
+			vd.errorf(path, "value %v is not a multiple of %v", f, m)
+		}
+	}
+}
+
+func (vd *validator) string(path location, s Schema, str string) {
+	n := float64(utf8.RuneCountInString(str))
+	if min, ok := Number(s, "minLength"); ok && n < min {
+		vd.errorf(path, "length %v is less than the minimum length %v", n, min)
+	}
+	if max, ok := Number(s, "maxLength"); ok && n > max {
+		vd.errorf(path, "length %v is greater than the maximum length %v", n, max)
+	}
+	if pattern := String(s, "pattern"); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			vd.errorf(path, "invalid pattern %q: %v", pattern, err)
+		} else if !re.MatchString(str) {
+			vd.errorf(path, "value %q does not match the pattern %q", str, pattern)
+		}
+	}
+}
+
+func (vd *validator) array(path location, s Schema, arr []interface{}, depth int) {
+	n := float64(len(arr))
+	if min, ok := Number(s, "minItems"); ok && n < min {
+		vd.errorf(path, "%v items is less than the minimum %v", n, min)
+	}
+	if max, ok := Number(s, "maxItems"); ok && n > max {
+		vd.errorf(path, "%v items is greater than the maximum %v", n, max)
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+		for i := range arr {
+			for j := i + 1; j < len(arr); j++ {
+				if reflect.DeepEqual(arr[i], arr[j]) {
+					vd.errorf(path, "items %d and %d are equal", i, j)
+				}
+			}
+		}
+	}
+	if items := Items(s); items != nil {
+		for i, item := range arr {
+			vd.validate(path.index(i), items, item, depth+1)
+		}
+	}
+}
+
+func (vd *validator) object(path location, s Schema, obj map[string]interface{}, depth int) {
+	for _, r := range Required(s) {
+		if _, ok := obj[r]; !ok {
+			vd.errorf(path, "missing required property %q", r)
+		}
+	}
+	props := Properties(s)
+	patterns := vd.patternProperties(path, s)
+	for _, k := range sortedKeys(obj) {
+		sub := path.key(k)
+		matched := false
+		if ps, ok := props[k]; ok {
+			vd.validate(sub, ps, obj[k], depth+1)
+			matched = true
+		}
+		for _, p := range patterns {
+			if p.re.MatchString(k) {
+				vd.validate(sub, p.schema, obj[k], depth+1)
+				matched = true
+			}
+		}
+		if matched {
+			continue
+		}
+		switch ap := s["additionalProperties"].(type) {
+		case bool:
+			if !ap {
+				vd.errorf(sub, "unknown property")
+			}
+		case map[string]interface{}:
+			vd.validate(sub, ap, obj[k], depth+1)
+		}
+	}
+}
+
+type patternProperty struct {
+	re     *regexp.Regexp
+	schema Schema
+}
+
+// patternProperties returns the schema's pattern properties, ordered by pattern.
+func (vd *validator) patternProperties(path location, s Schema) []patternProperty {
+	pp, ok := s["patternProperties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	out := []patternProperty{}
+	for _, pattern := range sortedKeys(pp) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			vd.errorf(path, "invalid pattern %q: %v", pattern, err)
+			continue
+		}
+		out = append(out, patternProperty{re: re, schema: FromValue(pp[pattern])})
+	}
+	return out
+}
+
+// IsType tells if the generic JSON value is of the named JSON schema type.
+func IsType(v interface{}, t string) bool {
+	switch t {
+	case "null":
+		return v == nil
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f) && !math.IsInf(f, 0)
+	case "array":
+		_, ok := v.([]interface{})
+		return ok
+	case "object":
+		_, ok := v.(map[string]interface{})
+		return ok
+	}
+	return false
+}
+
+// TypeOf returns the JSON schema type name of the generic JSON value.
+func TypeOf(v interface{}) string {
+	switch vv := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if vv == math.Trunc(vv) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func schemaList(v interface{}) []Schema {
+	arr, _ := v.([]interface{})
+	out := make([]Schema, 0, len(arr))
+	for _, a := range arr {
+		if s := FromValue(a); s != nil {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
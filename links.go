@@ -0,0 +1,294 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"go/ast"
+	"strconv"
+	"strings"
+
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// linkDirective declares a link from a method to another in the method's doc comment,
+// by the other method's name in the document and the params to call it with,
+// which are runtime expressions or JSON constants, eg.
+//
+//	//openrpc:link OrderService.GetOrder id=$result.id
+const linkDirective = "//openrpc:link "
+
+// directiveLinks returns the links declared by a method's doc comment.
+func directiveLinks(funcDecl *ast.FuncDecl) *meta_schema.MethodObjectLinks {
+	if funcDecl.Doc == nil {
+		return nil
+	}
+	links := meta_schema.MethodObjectLinks{}
+	for _, c := range funcDecl.Doc.List {
+		if !strings.HasPrefix(c.Text, linkDirective) {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(c.Text, linkDirective))
+		if len(fields) == 0 {
+			continue
+		}
+		params := map[string]interface{}{}
+		for _, f := range fields[1:] {
+			kv := strings.SplitN(f, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			var v interface{}
+			if strings.HasPrefix(kv[1], "$") || json.Unmarshal([]byte(kv[1]), &v) != nil {
+				v = kv[1]
+			}
+			params[kv[0]] = v
+		}
+		links = append(links, newLink(fields[0], params))
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	return &links
+}
+
+func newLink(method string, params map[string]interface{}) meta_schema.LinkOrReference {
+	name := method
+	var p meta_schema.LinkObjectParams = params
+	return meta_schema.LinkOrReference{LinkObject: &meta_schema.LinkObject{
+		Name:   (*meta_schema.LinkObjectName)(&name),
+		Method: (*meta_schema.LinkObjectMethod)(&method),
+		Params: &p,
+	}}
+}
+
+// RegisterLink declares a link from one of the document's methods to another, by their names in the document.
+// The link's name, which OpenRPC requires, defaults to that of the method it links to.
+func (d *Document) RegisterLink(method string, link meta_schema.LinkObject) {
+	if (link.Name == nil || *link.Name == "") && link.Method != nil {
+		name := string(*link.Method)
+		link.Name = (*meta_schema.LinkObjectName)(&name)
+	}
+	if d.links == nil {
+		d.links = map[string][]meta_schema.LinkObject{}
+	}
+	d.links[method] = append(d.links[method], link)
+}
+
+// WithLinkInference sets whether links are inferred between the document's methods.
+// A method is linked to another when the other's params can all be passed from the method's result:
+// each required param of the other method must share its name (ignoring case, '-' and '_')
+// and type with a property of the result, or with the result itself.
+// Methods without required params aren't linked to, since matching optional params alone
+// says little about whether the result is meant for them; matching optional params are
+// passed along in links made through a required one.
+func (d *Document) WithLinkInference(enabled bool) *Document {
+	d.inferLinks = enabled
+	return d
+}
+
+// linkMethods sets the links of the document's methods: those reflected, those registered,
+// and those inferred if enabled. Links to methods missing from the document, eg. filtered
+// for the caller, are dropped.
+func (d *Document) linkMethods(methods []meta_schema.MethodObject) {
+	names := map[string]bool{}
+	for _, m := range methods {
+		names[string(*m.Name)] = true
+	}
+
+	var schemas []linkSchema
+	if d.inferLinks {
+		schemas = make([]linkSchema, len(methods))
+		for i, m := range methods {
+			schemas[i] = newLinkSchema(m)
+		}
+	}
+
+	for i := range methods {
+		m := &methods[i]
+		links := meta_schema.MethodObjectLinks{}
+		linked := map[string]bool{}
+		add := func(l meta_schema.LinkOrReference) {
+			if l.LinkObject != nil && l.LinkObject.Method != nil {
+				target := string(*l.LinkObject.Method)
+				if !names[target] || linked[target] {
+					return
+				}
+				linked[target] = true
+			}
+			links = append(links, l)
+		}
+
+		if m.Links != nil {
+			for _, l := range *m.Links {
+				add(l)
+			}
+		}
+		for _, l := range d.links[string(*m.Name)] {
+			l := l
+			add(meta_schema.LinkOrReference{LinkObject: &l})
+		}
+		if d.inferLinks {
+			for j, target := range methods {
+				if i == j {
+					continue
+				}
+				if params, ok := schemas[i].linkParams(schemas[j]); ok {
+					add(newLink(string(*target.Name), params))
+				}
+			}
+		}
+
+		if len(links) == 0 {
+			m.Links = nil
+			continue
+		}
+		m.Links = &links
+	}
+}
+
+// linkSchema is what link inference knows of a method: its result and its params.
+type linkSchema struct {
+	result     linkValue
+	properties map[string]linkValue
+	params     []linkParam
+}
+
+type linkValue struct {
+	name string
+	ty   string
+}
+
+type linkParam struct {
+	linkValue
+	required bool
+}
+
+func newLinkSchema(m meta_schema.MethodObject) linkSchema {
+	out := linkSchema{properties: map[string]linkValue{}}
+	if m.Result != nil && m.Result.ContentDescriptorObject != nil {
+		cd := m.Result.ContentDescriptorObject
+		schema := genericSchema(cd.Schema)
+		out.result = linkValue{name: string(*cd.Name), ty: schemaType(schema)}
+		if props, ok := schema["properties"].(map[string]interface{}); ok {
+			for name, p := range props {
+				ps, _ := p.(map[string]interface{})
+				out.properties[linkNameKey(name)] = linkValue{name: name, ty: schemaType(resolveLocalRef(schema, ps))}
+			}
+		}
+	}
+	if m.Params != nil {
+		for _, p := range *m.Params {
+			cd := p.ContentDescriptorObject
+			if cd == nil || cd.Name == nil {
+				continue
+			}
+			out.params = append(out.params, linkParam{
+				linkValue: linkValue{name: string(*cd.Name), ty: schemaType(genericSchema(cd.Schema))},
+				required:  cd.Required != nil && bool(*cd.Required),
+			})
+		}
+	}
+	return out
+}
+
+// linkParams returns the params of a link from a method to a target method,
+// as runtime expressions of the method's result, and whether the link can be made:
+// all the target's required params, and at least one, must be matched.
+func (s linkSchema) linkParams(target linkSchema) (map[string]interface{}, bool) {
+	params := map[string]interface{}{}
+	required := false
+	for _, p := range target.params {
+		if p.ty == "" {
+			if p.required {
+				return nil, false
+			}
+			continue
+		}
+		key := linkNameKey(p.name)
+		if prop, ok := s.properties[key]; ok && prop.ty == p.ty {
+			params[p.name] = "$result." + prop.name
+		} else if s.result.ty == p.ty && linkNameKey(s.result.name) == key {
+			params[p.name] = "$result"
+		} else if p.required {
+			return nil, false
+		} else {
+			continue
+		}
+		required = required || p.required
+	}
+	return params, required
+}
+
+// linkNameKey normalizes a name for matching results and params.
+func linkNameKey(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// genericSchema returns a schema as decoded from JSON.
+func genericSchema(schema *meta_schema.JSONSchema) map[string]interface{} {
+	out := map[string]interface{}{}
+	if schema == nil {
+		return out
+	}
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return out
+	}
+	_ = json.Unmarshal(b, &out)
+	return out
+}
+
+// resolveLocalRef resolves a subschema's $ref to the root schema's definitions.
+func resolveLocalRef(root, schema map[string]interface{}) map[string]interface{} {
+	ref, ok := schema["$ref"].(string)
+	if !ok || !strings.HasPrefix(ref, "#/") {
+		return schema
+	}
+	if resolved, ok := resolvePointer(root, strings.TrimPrefix(ref, "#")).(map[string]interface{}); ok {
+		return resolved
+	}
+	return schema
+}
+
+// resolvePointer returns the value at the JSON pointer, or nil.
+func resolvePointer(v interface{}, pointer string) interface{} {
+	if pointer == "" {
+		return v
+	}
+	for _, tok := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		tok = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+		switch vv := v.(type) {
+		case map[string]interface{}:
+			v = vv[tok]
+		case []interface{}:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(vv) {
+				return nil
+			}
+			v = vv[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// schemaType returns a schema's type, or that of a nullable schema (see nullableSchema).
+// It returns "" for schemas without a single type.
+func schemaType(schema map[string]interface{}) string {
+	switch ty := schema["type"].(type) {
+	case string:
+		return ty
+	case []interface{}:
+		if len(ty) == 1 {
+			s, _ := ty[0].(string)
+			return s
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok && len(anyOf) == 2 {
+		if null, _ := anyOf[1].(map[string]interface{}); schemaType(null) == "null" {
+			s, _ := anyOf[0].(map[string]interface{})
+			return schemaType(s)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,137 @@
+package go_openrpc_reflect
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	meta_schema "github.com/open-rpc/meta-schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type linkOrderService struct{}
+
+type LinkOrder struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// CreateOrder places an order.
+func (s *linkOrderService) CreateOrder(item string) (*LinkOrder, error) {
+	return &LinkOrder{ID: "1", Status: "open"}, nil
+}
+
+// GetOrder returns an order.
+func (s *linkOrderService) GetOrder(id string) (*LinkOrder, error) {
+	return &LinkOrder{ID: id, Status: "open"}, nil
+}
+
+// CancelOrder cancels an order, with an optional reason.
+func (s *linkOrderService) CancelOrder(id string, reason *string) (bool, error) {
+	return true, nil
+}
+
+// ListOrders returns a page of orders with the status.
+//
+//openrpc:link order_getOrder id=$result.0.id
+func (s *linkOrderService) ListOrders(status string, page int) ([]LinkOrder, error) {
+	return nil, nil
+}
+
+func TestDocument_WithLinkInference(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(EthereumReflector).WithLinkInference(true)
+	d.RegisterReceiverName("order", &linkOrderService{})
+	d.RegisterLink("order_cancelOrder", meta_schema.LinkObject{
+		Method: (*meta_schema.LinkObjectMethod)(stringPtr("order_createOrder")),
+	})
+
+	out, err := d.Discover()
+	if !assert.NoError(t, err) {
+		t.Fatal("discover error, fatal")
+	}
+	b, _ := json.MarshalIndent(out, "", "  ")
+	t.Log(string(b))
+
+	testJSON(t, b, map[string]interface{}{
+		"methods.#":      float64(4),
+		"methods.0.name": "order_cancelOrder",
+		// Registered.
+		"methods.0.links.#":        float64(1),
+		"methods.0.links.0.method": "order_createOrder",
+		"methods.0.links.0.name":   "order_createOrder",
+
+		"methods.1.name": "order_createOrder",
+		// Inferred: the order's id is the only required param of cancelOrder and getOrder,
+		// and its status is a param of listOrders, whose page isn't a property of the order.
+		"methods.1.links.#":               float64(2),
+		"methods.1.links.0.method":        "order_cancelOrder",
+		"methods.1.links.0.params.id":     "$result.id",
+		"methods.1.links.0.params.reason": nil,
+		"methods.1.links.1.method":        "order_getOrder",
+		"methods.1.links.1.params.id":     "$result.id",
+
+		"methods.3.name": "order_listOrders",
+		// Declared by directive.
+		"methods.3.links.#":           float64(1),
+		"methods.3.links.0.method":    "order_getOrder",
+		"methods.3.links.0.params.id": "$result.0.id",
+	})
+}
+
+func TestDocument_WithLinkInference_Filtered(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(EthereumReflector).WithLinkInference(true)
+	d.RegisterReceiverName("order", &linkOrderService{})
+	d.WithMethodFilter(func(_ context.Context, _ string, _ interface{}, m meta_schema.MethodObject) bool {
+		return *m.Name != "order_getOrder"
+	})
+
+	out, err := d.Discover()
+	if !assert.NoError(t, err) {
+		t.Fatal("discover error, fatal")
+	}
+	b, _ := json.Marshal(out)
+	testJSON(t, b, map[string]interface{}{
+		"methods.#":                float64(3),
+		"methods.1.name":           "order_createOrder",
+		"methods.1.links.#":        float64(1),
+		"methods.1.links.0.method": "order_cancelOrder",
+		"methods.2.name":           "order_listOrders",
+		"methods.2.links":          nil,
+	})
+}
+
+type linkSearchService struct{}
+
+// CreateOrder places an order.
+func (s *linkSearchService) CreateOrder(item string) (*LinkOrder, error) {
+	return &LinkOrder{ID: "1", Status: "open"}, nil
+}
+
+// SearchOrders returns the orders matching the optional filters.
+func (s *linkSearchService) SearchOrders(id *string, status *string) ([]LinkOrder, error) {
+	return nil, nil
+}
+
+func TestDocument_WithLinkInference_OptionalParams(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(EthereumReflector).WithLinkInference(true)
+	d.RegisterReceiverName("order", &linkSearchService{})
+
+	out, err := d.Discover()
+	if !assert.NoError(t, err) {
+		t.Fatal("discover error, fatal")
+	}
+	b, _ := json.Marshal(out)
+	testJSON(t, b, map[string]interface{}{
+		"methods.#":      float64(2),
+		"methods.0.name": "order_createOrder",
+		// The order matches both params of searchOrders, but neither is required.
+		"methods.0.links":             nil,
+		"methods.1.name":              "order_searchOrders",
+		"methods.1.params.0.required": false,
+		"methods.1.params.1.required": false,
+	})
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,106 @@
+package go_openrpc_reflect
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/iancoleman/orderedmap"
+)
+
+// marshalerSchemaTypeMap returns a map documenting types which marshal themselves by their JSON, rather than by
+// their Go layout: encoding.TextMarshalers are strings, and the schemas of json.Marshalers are inferred from
+// marshaling their zero values. A json.Marshaler whose JSON can't be inferred, eg. marshaling as null, is any JSON,
+// and is warned about; give its schema with a SchemaTypeMap.
+// json.RawMessage, whose JSON only its producer knows, is any JSON, without a warning.
+func marshalerSchemaTypeMap(warn func(ty reflect.Type, message string)) SchemaTypeMap {
+	return func(ty reflect.Type) *jsonschema.Type {
+		// Pointers and interfaces are left to be dereferenced, or documented as any JSON.
+		if ty.Kind() == reflect.Ptr || ty.Kind() == reflect.Interface {
+			return nil
+		}
+		if ty == rawMessageType {
+			return &jsonschema.Type{}
+		}
+		ptr := reflect.PtrTo(ty)
+		switch {
+		case ty.Implements(jsonMarshalerType) || ptr.Implements(jsonMarshalerType):
+			b, err := marshalZeroValue(ty)
+			if err != nil {
+				warn(ty, fmt.Sprintf("cannot infer the schema of a json.Marshaler: %v", err))
+				return &jsonschema.Type{}
+			}
+			schema := schemaFromJSON(b)
+			if schema == nil {
+				warn(ty, fmt.Sprintf("cannot infer the schema of a json.Marshaler from its zero value's JSON: %s", b))
+				return &jsonschema.Type{}
+			}
+			return schema
+		case ty.Implements(textMarshalerType) || ptr.Implements(textMarshalerType):
+			return &jsonschema.Type{Type: "string"}
+		}
+		return nil
+	}
+}
+
+// marshalZeroValue marshals the zero value of a json.Marshaler, recovering marshalers which panic on it.
+func marshalZeroValue(ty reflect.Type) (b []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("zero value panics: %v", r)
+		}
+	}()
+	v := reflect.New(ty)
+	if ty.Implements(jsonMarshalerType) {
+		return v.Elem().Interface().(json.Marshaler).MarshalJSON()
+	}
+	return v.Interface().(json.Marshaler).MarshalJSON()
+}
+
+// schemaFromJSON infers a schema from a sample of JSON, or returns nil if it can't, ie. for null.
+// Object properties and array items sampled as null are any JSON.
+func schemaFromJSON(b []byte) *jsonschema.Type {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil || v == nil {
+		return nil
+	}
+	return schemaFromValue(v)
+}
+
+func schemaFromValue(v interface{}) *jsonschema.Type {
+	switch v := v.(type) {
+	case string:
+		return &jsonschema.Type{Type: "string"}
+	case json.Number:
+		return &jsonschema.Type{Type: "number"}
+	case bool:
+		return &jsonschema.Type{Type: "boolean"}
+	case []interface{}:
+		schema := &jsonschema.Type{Type: "array"}
+		if len(v) > 0 && v[0] != nil {
+			schema.Items = schemaFromValue(v[0])
+		}
+		return schema
+	case map[string]interface{}:
+		schema := &jsonschema.Type{Type: "object", Properties: orderedmap.New()}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			prop := &jsonschema.Type{}
+			if v[k] != nil {
+				prop = schemaFromValue(v[k])
+			}
+			schema.Properties.Set(k, prop)
+		}
+		return schema
+	}
+	return &jsonschema.Type{}
+}
@@ -0,0 +1,124 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/stretchr/testify/assert"
+)
+
+type marshalerID struct{ n int }
+
+func (id marshalerID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("id-%d", id.n)), nil
+}
+
+type marshalerPoint struct{ X, Y int }
+
+func (p marshalerPoint) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]int{p.X, p.Y})
+}
+
+type marshalerAccount struct {
+	balance int
+	owner   *string
+}
+
+func (a *marshalerAccount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{"balance": a.balance, "owner": a.owner, "active": true})
+}
+
+type marshalerNull struct{ Hidden int }
+
+func (marshalerNull) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
+
+type marshalerPanics struct{ name *string }
+
+func (m marshalerPanics) MarshalJSON() ([]byte, error) {
+	return json.Marshal(*m.name)
+}
+
+type MarshalerStruct struct {
+	ID      marshalerID       `json:"id"`
+	Point   marshalerPoint    `json:"point"`
+	Account *marshalerAccount `json:"account"`
+	Null    marshalerNull     `json:"null"`
+	Panics  marshalerPanics   `json:"panics"`
+}
+
+func TestMarshalerSchemas(t *testing.T) {
+	reflector := &StandardReflectorT{warnings: &schemaWarnings{}}
+	schema, err := buildJSONSchemaObject(reflector, reflect.Value{}, reflect.Method{Name: "Get"}, nil, reflect.TypeOf(MarshalerStruct{}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, _ := json.Marshal(schema)
+	t.Log(string(b))
+	testJSON(t, b, map[string]interface{}{
+		"properties.id.type":                         "string",
+		"properties.point.type":                      "array",
+		"properties.point.items.type":                "number",
+		"properties.account.type":                    "object",
+		"properties.account.properties.active.type":  "boolean",
+		"properties.account.properties.balance.type": "number",
+		"properties.account.properties.owner":        map[string]interface{}{},
+		"properties.null":                            map[string]interface{}{},
+		"properties.panics":                          map[string]interface{}{},
+	})
+
+	warnings := reflector.warnings.warnings
+	if !assert.Len(t, warnings, 2) {
+		t.FailNow()
+	}
+	assert.Equal(t, "Get", warnings[0].Method)
+	assert.Equal(t, reflect.TypeOf(marshalerNull{}), warnings[0].Type)
+	assert.Contains(t, warnings[0].String(), "null")
+	assert.Equal(t, reflect.TypeOf(marshalerPanics{}), warnings[1].Type)
+	assert.Contains(t, warnings[1].Message, "panics")
+
+	// Warnings are collected once.
+	_, _ = buildJSONSchemaObject(reflector, reflect.Value{}, reflect.Method{Name: "Get"}, nil, reflect.TypeOf(MarshalerStruct{}))
+	assert.Len(t, reflector.warnings.warnings, 2)
+}
+
+func TestMarshalerSchemas_TypeMap(t *testing.T) {
+	// A SchemaTypeMap gives the schemas of marshalers which can't be inferred.
+	reflector := &StandardReflectorT{warnings: &schemaWarnings{}}
+	reflector.FnSchemaTypeMap = func() func(ty reflect.Type) *jsonschema.Type {
+		return ComposeSchemaTypeMaps(func(ty reflect.Type) *jsonschema.Type {
+			if ty == reflect.TypeOf(marshalerNull{}) {
+				return &jsonschema.Type{Type: "integer"}
+			}
+			return nil
+		}, StandardSchemaTypeMap)
+	}
+	schema, err := buildJSONSchemaObject(reflector, reflect.Value{}, reflect.Method{}, nil, reflect.TypeOf(MarshalerStruct{}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, _ := json.Marshal(schema)
+	testJSON(t, b, map[string]interface{}{
+		"properties.null.type": "integer",
+	})
+	assert.Len(t, reflector.warnings.warnings, 1)
+}
+
+func TestMarshalerSchemas_RawMessage(t *testing.T) {
+	// json.RawMessage is any JSON, also without the StandardSchemaTypeMap, and isn't warned about.
+	reflector := &StandardReflectorT{warnings: &schemaWarnings{}}
+	reflector.FnSchemaTypeMap = func() func(ty reflect.Type) *jsonschema.Type {
+		return nil
+	}
+	schema, err := buildJSONSchemaObject(reflector, reflect.Value{}, reflect.Method{}, nil, reflect.TypeOf(json.RawMessage{}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, _ := json.Marshal(schema)
+	assert.JSONEq(t, `{}`, string(b))
+	assert.Empty(t, reflector.warnings.warnings)
+}
@@ -13,6 +13,8 @@ type MetaT struct {
 	GetServersFn func () func (listeners []net.Listener) (*meta_schema.Servers, error)
 	GetInfoFn  func () (info *meta_schema.InfoObject)
 	GetExternalDocsFn func () (exdocs *meta_schema.ExternalDocumentationObject)
+	// GetExtensionsFn, if set, returns the document's specification extensions; see Document.DiscoverExtended.
+	GetExtensionsFn func () (ext Extensions)
 }
 
 func (m *MetaT) GetServers() func (listeners []net.Listener) (*meta_schema.Servers, error) {
@@ -27,4 +29,6 @@ func (m *MetaT) GetExternalDocs() func() (exdocs *meta_schema.ExternalDocumentat
 	return m.GetExternalDocsFn
 }
 
-
+func (m *MetaT) GetExtensions() func() (ext Extensions) {
+	return m.GetExtensionsFn
+}
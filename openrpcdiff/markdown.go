@@ -0,0 +1,48 @@
+package openrpcdiff
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Markdown writes the changes as a Markdown changelog,
+// with breaking changes listed first.
+func Markdown(w io.Writer, changes []Change) error {
+	var breaking, compatible []Change
+	for _, c := range changes {
+		if c.Breaking {
+			breaking = append(breaking, c)
+		} else {
+			compatible = append(compatible, c)
+		}
+	}
+
+	b := &strings.Builder{}
+	b.WriteString("# API Changes\n\n")
+	if len(changes) == 0 {
+		b.WriteString("No changes.\n")
+	}
+	for _, section := range []struct {
+		title   string
+		changes []Change
+	}{
+		{"Breaking Changes", breaking},
+		{"Non-breaking Changes", compatible},
+	} {
+		if len(section.changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "## %s\n\n", section.title)
+		for _, c := range section.changes {
+			fmt.Fprintf(b, "- `%s`", c.Method)
+			if c.Path != "" {
+				fmt.Fprintf(b, " `%s`", c.Path)
+			}
+			fmt.Fprintf(b, ": %s\n", c.Message)
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, strings.TrimRight(b.String(), "\n")+"\n")
+	return err
+}
@@ -0,0 +1,585 @@
+// Package openrpcdiff compares two OpenRPC documents, eg. those discovered
+// before and after refactoring a receiver, and classifies every change
+// as breaking or non-breaking for existing clients.
+//
+// Params are inputs, so narrowing their schemas (accepting fewer values) breaks clients,
+// while widening them does not. Results are outputs, so the reverse holds.
+package openrpcdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/schemajson"
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// Kind classifies a Change.
+type Kind string
+
+const (
+	MethodAdded            Kind = "method-added"
+	MethodRemoved          Kind = "method-removed"
+	MethodDeprecated       Kind = "method-deprecated"
+	MethodUndeprecated     Kind = "method-undeprecated"
+	ParamStructureChanged  Kind = "param-structure-changed"
+	ParamAdded             Kind = "param-added"
+	ParamRemoved           Kind = "param-removed"
+	ParamRenamed           Kind = "param-renamed"
+	ParamReordered         Kind = "param-reordered"
+	ParamDeprecated        Kind = "param-deprecated"
+	RequiredChanged        Kind = "required-changed"
+	ResultAdded            Kind = "result-added"
+	ResultRemoved          Kind = "result-removed"
+	SchemaNarrowed         Kind = "schema-narrowed"
+	SchemaWidened          Kind = "schema-widened"
+	SchemaChanged          Kind = "schema-changed"
+	EnumChanged            Kind = "enum-changed"
+	PropertyAdded          Kind = "property-added"
+	PropertyRemoved        Kind = "property-removed"
+	PropertyRequiredChange Kind = "property-required-changed"
+)
+
+// Change describes one difference between two documents.
+type Change struct {
+	Kind Kind
+
+	// Method is the name of the method the change was found in.
+	Method string
+
+	// Path locates the changed value within the method, eg. "params.argA.schema.type".
+	// It is empty for changes to the method itself.
+	Path string
+
+	// Message describes the change in human terms.
+	Message string
+
+	// Breaking tells if existing clients of the old document may fail against the new one.
+	Breaking bool
+}
+
+func (c Change) String() string {
+	s := c.Method
+	if c.Path != "" {
+		s += ": " + c.Path
+	}
+	s += ": " + c.Message
+	if c.Breaking {
+		s += " (breaking)"
+	}
+	return s
+}
+
+// HasBreaking tells if any of the changes are breaking.
+func HasBreaking(changes []Change) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare returns the changes from the old document to the new one,
+// ordered by method name.
+func Compare(old, new *meta_schema.OpenrpcDocument) []Change {
+	c := &comparer{old: old, new: new}
+
+	oldMethods, newMethods := methodsByName(old), methodsByName(new)
+	names := []string{}
+	for name := range oldMethods {
+		names = append(names, name)
+	}
+	for name := range newMethods {
+		if _, ok := oldMethods[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		o, ook := oldMethods[name]
+		n, nok := newMethods[name]
+		switch {
+		case !nok:
+			msg := "method removed"
+			if isDeprecated(o.Deprecated) {
+				msg = "deprecated method removed"
+			}
+			c.add(MethodRemoved, name, "", true, msg)
+		case !ook:
+			c.add(MethodAdded, name, "", false, "method added")
+		default:
+			c.method(name, o, n)
+		}
+	}
+	return c.changes
+}
+
+// direction tells if a schema describes values sent by clients (input)
+// or returned to them (output).
+type direction int
+
+const (
+	input direction = iota
+	output
+)
+
+type comparer struct {
+	old, new *meta_schema.OpenrpcDocument
+	changes  []Change
+}
+
+func (c *comparer) add(kind Kind, method, path string, breaking bool, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{
+		Kind:     kind,
+		Method:   method,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+		Breaking: breaking,
+	})
+}
+
+func methodsByName(doc *meta_schema.OpenrpcDocument) map[string]meta_schema.MethodObject {
+	out := map[string]meta_schema.MethodObject{}
+	if doc == nil || doc.Methods == nil {
+		return out
+	}
+	for _, m := range *doc.Methods {
+		if m.Name != nil {
+			out[string(*m.Name)] = m
+		}
+	}
+	return out
+}
+
+func isDeprecated(d interface{}) bool {
+	switch v := d.(type) {
+	case *meta_schema.MethodObjectDeprecated:
+		return v != nil && bool(*v)
+	case *meta_schema.ContentDescriptorObjectDeprecated:
+		return v != nil && bool(*v)
+	}
+	return false
+}
+
+func isRequired(r *meta_schema.ContentDescriptorObjectRequired) bool {
+	return r != nil && bool(*r)
+}
+
+// paramStructure returns the method's param structure, defaulting to "either" per the spec.
+func paramStructure(m meta_schema.MethodObject) string {
+	if m.ParamStructure == nil || *m.ParamStructure == "" {
+		return "either"
+	}
+	return string(*m.ParamStructure)
+}
+
+func (c *comparer) method(name string, o, n meta_schema.MethodObject) {
+	if od, nd := isDeprecated(o.Deprecated), isDeprecated(n.Deprecated); od != nd {
+		if nd {
+			c.add(MethodDeprecated, name, "", false, "method deprecated")
+		} else {
+			c.add(MethodUndeprecated, name, "", false, "method no longer deprecated")
+		}
+	}
+
+	oldStructure, newStructure := paramStructure(o), paramStructure(n)
+	if oldStructure != newStructure {
+		// Moving to "either" accepts everything that was accepted before.
+		c.add(ParamStructureChanged, name, "paramStructure", newStructure != "either",
+			"param structure changed from %s to %s", oldStructure, newStructure)
+	}
+	// Positions matter to clients unless the old method only accepted params by name,
+	// and names matter unless it only accepted them by position.
+	byPosition, byName := oldStructure != "by-name", oldStructure != "by-position"
+
+	oldParams, err := c.params(c.old, o)
+	if err != nil {
+		c.add(SchemaChanged, name, "params", true, "%v", err)
+		return
+	}
+	newParams, err := c.params(c.new, n)
+	if err != nil {
+		c.add(SchemaChanged, name, "params", true, "%v", err)
+		return
+	}
+
+	oldIndex, newIndex := paramIndex(oldParams), paramIndex(newParams)
+
+	// A param is considered renamed when its old name is gone and
+	// a new name has taken its place at the same position.
+	renamed, renamedTo := map[string]string{}, map[string]bool{}
+	for i, op := range oldParams {
+		if _, ok := newIndex[cdName(op)]; ok || i >= len(newParams) {
+			continue
+		}
+		np := newParams[i]
+		if _, ok := oldIndex[cdName(np)]; ok {
+			continue
+		}
+		renamed[cdName(op)] = cdName(np)
+		renamedTo[cdName(np)] = true
+		c.add(ParamRenamed, name, "params."+cdName(op), byName, "param %q renamed to %q", cdName(op), cdName(np))
+	}
+
+	for i, op := range oldParams {
+		nname, ok := renamed[cdName(op)]
+		if !ok {
+			nname = cdName(op)
+		}
+		j, ok := newIndex[nname]
+		if !ok {
+			c.add(ParamRemoved, name, "params."+cdName(op), true, "param %q removed", cdName(op))
+			continue
+		}
+		if i != j {
+			c.add(ParamReordered, name, "params."+cdName(op), byPosition, "param %q moved from position %d to %d", cdName(op), i, j)
+		}
+		c.param(name, "params."+nname, op, newParams[j])
+	}
+
+	for _, np := range newParams {
+		if _, ok := oldIndex[cdName(np)]; ok || renamedTo[cdName(np)] {
+			continue
+		}
+		if isRequired(np.Required) {
+			c.add(ParamAdded, name, "params."+cdName(np), true, "required param %q added", cdName(np))
+		} else {
+			c.add(ParamAdded, name, "params."+cdName(np), false, "optional param %q added", cdName(np))
+		}
+	}
+
+	c.result(name, o, n)
+}
+
+func (c *comparer) param(method, path string, o, n meta_schema.ContentDescriptorObject) {
+	if isRequired(o.Required) != isRequired(n.Required) {
+		if isRequired(n.Required) {
+			c.add(RequiredChanged, method, path+".required", true, "param became required")
+		} else {
+			c.add(RequiredChanged, method, path+".required", false, "param became optional")
+		}
+	}
+	if !isDeprecated(o.Deprecated) && isDeprecated(n.Deprecated) {
+		c.add(ParamDeprecated, method, path, false, "param deprecated")
+	}
+	c.contentDescriptorSchema(method, path+".schema", o, n, input)
+}
+
+func (c *comparer) result(method string, o, n meta_schema.MethodObject) {
+	or, err := c.resolveResult(c.old, o)
+	if err != nil {
+		c.add(SchemaChanged, method, "result", true, "%v", err)
+		return
+	}
+	nr, err := c.resolveResult(c.new, n)
+	if err != nil {
+		c.add(SchemaChanged, method, "result", true, "%v", err)
+		return
+	}
+	switch {
+	case or == nil && nr == nil:
+	case or == nil:
+		c.add(ResultAdded, method, "result", false, "result added")
+	case nr == nil:
+		c.add(ResultRemoved, method, "result", true, "result removed")
+	default:
+		// Result names are not part of the wire format, so renaming them is not a change.
+		c.contentDescriptorSchema(method, "result.schema", *or, *nr, output)
+	}
+}
+
+func (c *comparer) contentDescriptorSchema(method, path string, o, n meta_schema.ContentDescriptorObject, dir direction) {
+	oldSchema, err := schemajson.FromJSONSchema(o.Schema)
+	if err != nil {
+		c.add(SchemaChanged, method, path, true, "invalid old schema: %v", err)
+		return
+	}
+	newSchema, err := schemajson.FromJSONSchema(n.Schema)
+	if err != nil {
+		c.add(SchemaChanged, method, path, true, "invalid new schema: %v", err)
+		return
+	}
+	c.schema(method, path, c.resolve(c.old, oldSchema), c.resolve(c.new, newSchema), dir, 0)
+}
+
+// maxDepth guards against recursive schemas.
+const maxDepth = 16
+
+// schema compares two schemas found at path.
+// A nil schema accepts (or may produce) any value.
+func (c *comparer) schema(method, path string, o, n schemajson.Schema, dir direction, depth int) {
+	if depth > maxDepth {
+		return
+	}
+	// narrowed reports a change that accepts fewer values,
+	// which breaks inputs; widening breaks outputs.
+	narrowed := func(format string, args ...interface{}) {
+		c.add(SchemaNarrowed, method, path, dir == input, format, args...)
+	}
+	widened := func(format string, args ...interface{}) {
+		c.add(SchemaWidened, method, path, dir == output, format, args...)
+	}
+
+	switch {
+	case o == nil && n == nil:
+		return
+	case o == nil:
+		narrowed("schema constrained")
+		return
+	case n == nil:
+		widened("schema constraints removed")
+		return
+	}
+
+	ot, nt := schemajson.Types(o), schemajson.Types(n)
+	switch {
+	case len(ot) == 0 && len(nt) == 0:
+	case len(ot) == 0:
+		narrowed("type constrained to %v", nt)
+	case len(nt) == 0:
+		widened("type constraint %v removed", ot)
+	default:
+		oInN, nInO := typesCovered(ot, nt), typesCovered(nt, ot)
+		switch {
+		case oInN && nInO:
+		case oInN:
+			widened("type widened from %v to %v", ot, nt)
+		case nInO:
+			narrowed("type narrowed from %v to %v", ot, nt)
+		default:
+			c.add(SchemaChanged, method, path, true, "type changed from %v to %v", ot, nt)
+			// The remaining keywords are meaningless across unrelated types.
+			return
+		}
+	}
+
+	c.enum(method, path+".enum", schemajson.Enum(o), schemajson.Enum(n), dir)
+	c.properties(method, path, o, n, dir, depth)
+
+	oi, ni := schemajson.Items(o), schemajson.Items(n)
+	if oi != nil || ni != nil {
+		c.schema(method, path+".items", c.resolve(c.old, oi), c.resolve(c.new, ni), dir, depth+1)
+	}
+}
+
+// typesCovered tells if every type in a is accepted by some type in b.
+// Integers are numbers.
+func typesCovered(a, b []string) bool {
+	for _, t := range a {
+		ok := false
+		for _, u := range b {
+			if t == u || (t == "integer" && u == "number") {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *comparer) enum(method, path string, o, n []interface{}, dir direction) {
+	if o == nil && n == nil {
+		return
+	}
+	if o == nil {
+		c.add(EnumChanged, method, path, dir == input, "values restricted to %s", enumString(n))
+		return
+	}
+	if n == nil {
+		c.add(EnumChanged, method, path, dir == output, "value restriction %s removed", enumString(o))
+		return
+	}
+	added, removed := enumDiff(o, n), enumDiff(n, o)
+	if len(removed) > 0 {
+		c.add(EnumChanged, method, path, dir == input, "values removed: %s", enumString(removed))
+	}
+	if len(added) > 0 {
+		c.add(EnumChanged, method, path, dir == output, "values added: %s", enumString(added))
+	}
+}
+
+// enumDiff returns the values of b which are not in a.
+func enumDiff(a, b []interface{}) []interface{} {
+	seen := map[string]bool{}
+	for _, v := range a {
+		seen[fmt.Sprintf("%#v", v)] = true
+	}
+	out := []interface{}{}
+	for _, v := range b {
+		if !seen[fmt.Sprintf("%#v", v)] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func enumString(vs []interface{}) string {
+	strs := make([]string, len(vs))
+	for i, v := range vs {
+		if s, ok := v.(string); ok {
+			strs[i] = fmt.Sprintf("%q", s)
+		} else {
+			strs[i] = fmt.Sprint(v)
+		}
+	}
+	return "[" + strings.Join(strs, ", ") + "]"
+}
+
+func (c *comparer) properties(method, path string, o, n schemajson.Schema, dir direction, depth int) {
+	op, np := schemajson.Properties(o), schemajson.Properties(n)
+	or, nr := stringSet(schemajson.Required(o)), stringSet(schemajson.Required(n))
+
+	for _, p := range schemajson.PropertyNames(o) {
+		ppath := path + ".properties." + p
+		nps, ok := np[p]
+		if !ok {
+			// Removed inputs are silently ignored by servers,
+			// and removed outputs are missed by clients.
+			c.add(PropertyRemoved, method, ppath, true, "property %q removed", p)
+			continue
+		}
+		if or[p] != nr[p] {
+			// A property becoming required narrows inputs,
+			// and one becoming optional widens outputs (it may now be missing).
+			breaking := (dir == input && nr[p]) || (dir == output && !nr[p])
+			state := "optional"
+			if nr[p] {
+				state = "required"
+			}
+			c.add(PropertyRequiredChange, method, ppath, breaking, "property %q became %s", p, state)
+		}
+		c.schema(method, ppath, c.resolve(c.old, op[p]), c.resolve(c.new, nps), dir, depth+1)
+	}
+	for _, p := range schemajson.PropertyNames(n) {
+		if _, ok := op[p]; ok {
+			continue
+		}
+		ppath := path + ".properties." + p
+		if dir == input && nr[p] {
+			c.add(PropertyAdded, method, ppath, true, "required property %q added", p)
+		} else {
+			c.add(PropertyAdded, method, ppath, false, "property %q added", p)
+		}
+	}
+}
+
+func stringSet(strs []string) map[string]bool {
+	out := map[string]bool{}
+	for _, s := range strs {
+		out[s] = true
+	}
+	return out
+}
+
+const componentsSchemasPrefix = "#/components/schemas/"
+
+// resolve follows a component schema reference.
+// References which can't be resolved are left as they are.
+func (c *comparer) resolve(doc *meta_schema.OpenrpcDocument, s schemajson.Schema) schemajson.Schema {
+	for i := 0; i < maxDepth; i++ {
+		ref := schemajson.String(s, "$ref")
+		if ref == "" || !strings.HasPrefix(ref, componentsSchemasPrefix) {
+			return s
+		}
+		if doc.Components == nil || doc.Components.Schemas == nil {
+			return s
+		}
+		v, ok := (*doc.Components.Schemas)[strings.TrimPrefix(ref, componentsSchemasPrefix)]
+		if !ok {
+			return s
+		}
+		s = schemajson.FromValue(v)
+	}
+	return s
+}
+
+func cdName(cd meta_schema.ContentDescriptorObject) string {
+	if cd.Name == nil {
+		return ""
+	}
+	return string(*cd.Name)
+}
+
+func paramIndex(params []meta_schema.ContentDescriptorObject) map[string]int {
+	out := map[string]int{}
+	for i, p := range params {
+		out[cdName(p)] = i
+	}
+	return out
+}
+
+const componentsContentDescriptorsPrefix = "#/components/contentDescriptors/"
+
+func (c *comparer) resolveContentDescriptor(doc *meta_schema.OpenrpcDocument, ref *meta_schema.ReferenceObject) (*meta_schema.ContentDescriptorObject, error) {
+	if ref == nil || ref.Ref == nil {
+		return nil, nil
+	}
+	r := string(*ref.Ref)
+	if strings.HasPrefix(r, componentsContentDescriptorsPrefix) && doc.Components != nil && doc.Components.ContentDescriptors != nil {
+		if v, ok := (*doc.Components.ContentDescriptors)[strings.TrimPrefix(r, componentsContentDescriptorsPrefix)]; ok {
+			// Components are generic values; round-trip them into the typed form.
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			cd := &meta_schema.ContentDescriptorObject{}
+			if err := json.Unmarshal(b, cd); err != nil {
+				return nil, fmt.Errorf("%s: %w", r, err)
+			}
+			return cd, nil
+		}
+	}
+	return nil, fmt.Errorf("unresolved reference: %s", r)
+}
+
+func (c *comparer) params(doc *meta_schema.OpenrpcDocument, m meta_schema.MethodObject) ([]meta_schema.ContentDescriptorObject, error) {
+	out := []meta_schema.ContentDescriptorObject{}
+	if m.Params == nil {
+		return out, nil
+	}
+	for _, p := range *m.Params {
+		if p.ContentDescriptorObject != nil {
+			out = append(out, *p.ContentDescriptorObject)
+			continue
+		}
+		cd, err := c.resolveContentDescriptor(doc, p.ReferenceObject)
+		if err != nil {
+			return nil, err
+		}
+		if cd != nil {
+			out = append(out, *cd)
+		}
+	}
+	return out, nil
+}
+
+// resolveResult returns the method's result, or nil if it has none.
+// Results with a "null" schema are treated as absent.
+func (c *comparer) resolveResult(doc *meta_schema.OpenrpcDocument, m meta_schema.MethodObject) (*meta_schema.ContentDescriptorObject, error) {
+	if m.Result == nil {
+		return nil, nil
+	}
+	cd := m.Result.ContentDescriptorObject
+	if cd == nil {
+		var err error
+		cd, err = c.resolveContentDescriptor(doc, m.Result.ReferenceObject)
+		if err != nil || cd == nil {
+			return nil, err
+		}
+	}
+	s, err := schemajson.FromJSONSchema(cd.Schema)
+	if err != nil {
+		return nil, err
+	}
+	if t := schemajson.Types(s); len(t) == 1 && t[0] == "null" {
+		return nil, nil
+	}
+	return cd, nil
+}
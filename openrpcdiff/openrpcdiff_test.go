@@ -0,0 +1,284 @@
+package openrpcdiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	meta_schema "github.com/open-rpc/meta-schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustDocument(t *testing.T, methods string) *meta_schema.OpenrpcDocument {
+	t.Helper()
+	doc := &meta_schema.OpenrpcDocument{}
+	err := json.Unmarshal([]byte(`{
+		"openrpc": "1.2.6",
+		"info": {"title": "test", "version": "1.0.0"},
+		"methods": `+methods+`,
+		"components": {
+			"schemas": {
+				"Integer": {"type": "integer"}
+			}
+		}
+	}`), doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestCompare(t *testing.T) {
+	const base = `[{
+		"name": "add",
+		"params": [
+			{"name": "a", "required": true, "schema": {"type": "integer"}},
+			{"name": "b", "required": true, "schema": {"type": "integer"}}
+		],
+		"result": {"name": "sum", "schema": {"type": "integer"}}
+	}]`
+
+	cases := []struct {
+		name   string
+		old    string
+		new    string
+		expect []Change
+	}{
+		{
+			name: "unchanged",
+			old:  base,
+			new:  base,
+		},
+		{
+			name: "method removed",
+			old:  base,
+			new:  `[]`,
+			expect: []Change{
+				{Kind: MethodRemoved, Method: "add", Message: "method removed", Breaking: true},
+			},
+		},
+		{
+			name: "method added",
+			old:  `[]`,
+			new:  base,
+			expect: []Change{
+				{Kind: MethodAdded, Method: "add", Message: "method added"},
+			},
+		},
+		{
+			name: "deprecated",
+			old:  base,
+			new: `[{"name": "add", "deprecated": true, "params": [
+				{"name": "a", "required": true, "schema": {"type": "integer"}},
+				{"name": "b", "required": true, "schema": {"type": "integer"}}
+			], "result": {"name": "sum", "schema": {"type": "integer"}}}]`,
+			expect: []Change{
+				{Kind: MethodDeprecated, Method: "add", Message: "method deprecated"},
+			},
+		},
+		{
+			name: "param renamed",
+			old:  base,
+			new: `[{"name": "add", "params": [
+				{"name": "a", "required": true, "schema": {"type": "integer"}},
+				{"name": "c", "required": true, "schema": {"type": "integer"}}
+			], "result": {"name": "total", "schema": {"type": "integer"}}}]`,
+			expect: []Change{
+				{Kind: ParamRenamed, Method: "add", Path: "params.b", Message: `param "b" renamed to "c"`, Breaking: true},
+			},
+		},
+		{
+			name: "param renamed by-position",
+			old: `[{"name": "add", "paramStructure": "by-position", "params": [
+				{"name": "a", "schema": {"type": "integer"}}
+			], "result": {"name": "sum", "schema": {"type": "integer"}}}]`,
+			new: `[{"name": "add", "paramStructure": "by-position", "params": [
+				{"name": "x", "schema": {"type": "integer"}}
+			], "result": {"name": "sum", "schema": {"type": "integer"}}}]`,
+			expect: []Change{
+				{Kind: ParamRenamed, Method: "add", Path: "params.a", Message: `param "a" renamed to "x"`},
+			},
+		},
+		{
+			name: "params reordered",
+			old:  base,
+			new: `[{"name": "add", "params": [
+				{"name": "b", "required": true, "schema": {"type": "integer"}},
+				{"name": "a", "required": true, "schema": {"type": "integer"}}
+			], "result": {"name": "sum", "schema": {"type": "integer"}}}]`,
+			expect: []Change{
+				{Kind: ParamReordered, Method: "add", Path: "params.a", Message: `param "a" moved from position 0 to 1`, Breaking: true},
+				{Kind: ParamReordered, Method: "add", Path: "params.b", Message: `param "b" moved from position 1 to 0`, Breaking: true},
+			},
+		},
+		{
+			name: "params reordered by-name",
+			old: `[{"name": "add", "paramStructure": "by-name", "params": [
+				{"name": "a", "schema": {"type": "integer"}},
+				{"name": "b", "schema": {"type": "integer"}}
+			], "result": {"name": "sum", "schema": {"type": "integer"}}}]`,
+			new: `[{"name": "add", "paramStructure": "by-name", "params": [
+				{"name": "b", "schema": {"type": "integer"}},
+				{"name": "a", "schema": {"type": "integer"}}
+			], "result": {"name": "sum", "schema": {"type": "integer"}}}]`,
+			expect: []Change{
+				{Kind: ParamReordered, Method: "add", Path: "params.a", Message: `param "a" moved from position 0 to 1`},
+				{Kind: ParamReordered, Method: "add", Path: "params.b", Message: `param "b" moved from position 1 to 0`},
+			},
+		},
+		{
+			name: "params added and removed",
+			old:  base,
+			new: `[{"name": "add", "params": [
+				{"name": "a", "required": true, "schema": {"type": "integer"}},
+				{"name": "b", "required": true, "schema": {"type": "integer"}},
+				{"name": "c", "required": false, "schema": {"type": "integer"}},
+				{"name": "d", "required": true, "schema": {"type": "integer"}}
+			], "result": {"name": "sum", "schema": {"type": "integer"}}}]`,
+			expect: []Change{
+				{Kind: ParamAdded, Method: "add", Path: "params.c", Message: `optional param "c" added`},
+				{Kind: ParamAdded, Method: "add", Path: "params.d", Message: `required param "d" added`, Breaking: true},
+			},
+		},
+		{
+			name: "param removed",
+			old:  base,
+			new: `[{"name": "add", "params": [
+				{"name": "a", "required": true, "schema": {"type": "integer"}}
+			], "result": {"name": "sum", "schema": {"type": "integer"}}}]`,
+			expect: []Change{
+				{Kind: ParamRemoved, Method: "add", Path: "params.b", Message: `param "b" removed`, Breaking: true},
+			},
+		},
+		{
+			name: "required flags",
+			old:  base,
+			new: `[{"name": "add", "params": [
+				{"name": "a", "required": false, "schema": {"type": "integer"}},
+				{"name": "b", "required": true, "schema": {"type": "integer"}}
+			], "result": {"name": "sum", "schema": {"type": "integer"}}}]`,
+			expect: []Change{
+				{Kind: RequiredChanged, Method: "add", Path: "params.a.required", Message: "param became optional"},
+			},
+		},
+		{
+			name: "param widened, result narrowed",
+			old:  base,
+			new: `[{"name": "add", "params": [
+				{"name": "a", "required": true, "schema": {"type": "number"}},
+				{"name": "b", "required": true, "schema": {"type": ["integer", "null"]}}
+			], "result": {"name": "sum", "schema": {"type": "integer", "enum": [1, 2]}}}]`,
+			expect: []Change{
+				{Kind: SchemaWidened, Method: "add", Path: "params.a.schema", Message: "type widened from [integer] to [number]"},
+				{Kind: SchemaWidened, Method: "add", Path: "params.b.schema", Message: "type widened from [integer] to [integer null]"},
+				{Kind: EnumChanged, Method: "add", Path: "result.schema.enum", Message: "values restricted to [1, 2]"},
+			},
+		},
+		{
+			name: "param narrowed, result widened",
+			old: `[{"name": "add", "params": [
+				{"name": "a", "required": true, "schema": {"type": "number"}}
+			], "result": {"name": "sum", "schema": {"type": "integer"}}}]`,
+			new: `[{"name": "add", "params": [
+				{"name": "a", "required": true, "schema": {"type": "integer"}}
+			], "result": {"name": "sum", "schema": {"type": ["integer", "null"]}}}]`,
+			expect: []Change{
+				{Kind: SchemaNarrowed, Method: "add", Path: "params.a.schema", Message: "type narrowed from [number] to [integer]", Breaking: true},
+				{Kind: SchemaWidened, Method: "add", Path: "result.schema", Message: "type widened from [integer] to [integer null]", Breaking: true},
+			},
+		},
+		{
+			name: "type changed",
+			old:  base,
+			new: `[{"name": "add", "params": [
+				{"name": "a", "required": true, "schema": {"type": "string"}},
+				{"name": "b", "required": true, "schema": {"$ref": "#/components/schemas/Integer"}}
+			], "result": {"name": "sum", "schema": {"type": "integer"}}}]`,
+			expect: []Change{
+				{Kind: SchemaChanged, Method: "add", Path: "params.a.schema", Message: "type changed from [integer] to [string]", Breaking: true},
+			},
+		},
+		{
+			name: "enums",
+			old: `[{"name": "color", "params": [
+				{"name": "c", "schema": {"type": "string", "enum": ["red", "green"]}}
+			], "result": {"name": "r", "schema": {"type": "string", "enum": ["red", "green"]}}}]`,
+			new: `[{"name": "color", "params": [
+				{"name": "c", "schema": {"type": "string", "enum": ["red", "blue"]}}
+			], "result": {"name": "r", "schema": {"type": "string", "enum": ["red", "green", "blue"]}}}]`,
+			expect: []Change{
+				{Kind: EnumChanged, Method: "color", Path: "params.c.schema.enum", Message: `values removed: ["green"]`, Breaking: true},
+				{Kind: EnumChanged, Method: "color", Path: "params.c.schema.enum", Message: `values added: ["blue"]`},
+				{Kind: EnumChanged, Method: "color", Path: "result.schema.enum", Message: `values added: ["blue"]`, Breaking: true},
+			},
+		},
+		{
+			name: "properties",
+			old: `[{"name": "put", "params": [
+				{"name": "item", "schema": {"type": "object", "required": ["id"], "properties": {
+					"id": {"type": "string"},
+					"note": {"type": "string"}
+				}}}
+			], "result": {"name": "item", "schema": {"type": "object", "required": ["id"], "properties": {
+				"id": {"type": "string"},
+				"items": {"type": "array", "items": {"type": "integer"}}
+			}}}}]`,
+			new: `[{"name": "put", "params": [
+				{"name": "item", "schema": {"type": "object", "required": ["id", "owner"], "properties": {
+					"id": {"type": "string"},
+					"owner": {"type": "string"},
+					"tag": {"type": "string"}
+				}}}
+			], "result": {"name": "item", "schema": {"type": "object", "properties": {
+				"id": {"type": "string"},
+				"items": {"type": "array", "items": {"type": "string"}},
+				"extra": {"type": "string"}
+			}}}}]`,
+			expect: []Change{
+				{Kind: PropertyRemoved, Method: "put", Path: "params.item.schema.properties.note", Message: `property "note" removed`, Breaking: true},
+				{Kind: PropertyAdded, Method: "put", Path: "params.item.schema.properties.owner", Message: `required property "owner" added`, Breaking: true},
+				{Kind: PropertyAdded, Method: "put", Path: "params.item.schema.properties.tag", Message: `property "tag" added`},
+				{Kind: PropertyRequiredChange, Method: "put", Path: "result.schema.properties.id", Message: `property "id" became optional`, Breaking: true},
+				{Kind: SchemaChanged, Method: "put", Path: "result.schema.properties.items.items", Message: "type changed from [integer] to [string]", Breaking: true},
+				{Kind: PropertyAdded, Method: "put", Path: "result.schema.properties.extra", Message: `property "extra" added`},
+			},
+		},
+		{
+			name: "result removed",
+			old:  base,
+			new: `[{"name": "add", "params": [
+				{"name": "a", "required": true, "schema": {"type": "integer"}},
+				{"name": "b", "required": true, "schema": {"type": "integer"}}
+			], "result": {"name": "null", "schema": {"type": "null"}}}]`,
+			expect: []Change{
+				{Kind: ResultRemoved, Method: "add", Path: "result", Message: "result removed", Breaking: true},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := Compare(mustDocument(t, c.old), mustDocument(t, c.new))
+			assert.Equal(t, c.expect, got)
+			assert.Equal(t, HasBreaking(c.expect), HasBreaking(got))
+		})
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	changes := []Change{
+		{Kind: MethodAdded, Method: "sub", Message: "method added"},
+		{Kind: ParamRemoved, Method: "add", Path: "params.b", Message: `param "b" removed`, Breaking: true},
+	}
+	buf := &bytes.Buffer{}
+	assert.NoError(t, Markdown(buf, changes))
+	assert.Equal(t, "# API Changes\n\n"+
+		"## Breaking Changes\n\n"+
+		"- `add` `params.b`: param \"b\" removed\n\n"+
+		"## Non-breaking Changes\n\n"+
+		"- `sub`: method added\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, Markdown(buf, nil))
+	assert.Equal(t, "# API Changes\n\nNo changes.\n", buf.String())
+}
@@ -0,0 +1,335 @@
+package openrpcfuzz
+
+import (
+	"math"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/schemajson"
+)
+
+// Mode selects the kind of params generated.
+type Mode uint8
+
+const (
+	// Conforming params satisfy their schemas.
+	Conforming Mode = iota
+	// Boundary params satisfy their schemas with extreme values:
+	// minimums and maximums, zero values, empty and maximum length strings and arrays.
+	Boundary
+	// Invalid params have one param deliberately not satisfying its schema.
+	Invalid
+
+	numModes
+)
+
+func (m Mode) String() string {
+	switch m {
+	case Conforming:
+		return "conforming"
+	case Boundary:
+		return "boundary"
+	case Invalid:
+		return "invalid"
+	}
+	return "unknown"
+}
+
+// source is a deterministic stream of choices read from fuzz input.
+// Once the input is exhausted, every choice is the first option.
+type source struct {
+	data []byte
+}
+
+func (s *source) byte() byte {
+	if len(s.data) == 0 {
+		return 0
+	}
+	b := s.data[0]
+	s.data = s.data[1:]
+	return b
+}
+
+// intn returns a choice in [0, n).
+func (s *source) intn(n int) int {
+	if n <= 1 {
+		return 0
+	}
+	if n <= 256 {
+		return int(s.byte()) % n
+	}
+	return int(uint16(s.byte())<<8|uint16(s.byte())) % n
+}
+
+func (s *source) bool() bool {
+	return s.byte()&1 == 1
+}
+
+// maxDepth bounds nesting, and maxItems the length of generated arrays and strings.
+const (
+	maxDepth = 6
+	maxItems = 4
+)
+
+type generator struct {
+	src     *source
+	resolve schemajson.Resolver
+	// boundary selects extreme values.
+	boundary bool
+}
+
+func (g *generator) deref(s schemajson.Schema) schemajson.Schema {
+	for i := 0; i < maxDepth && s != nil; i++ {
+		ref := schemajson.String(s, "$ref")
+		if ref == "" || g.resolve == nil {
+			return s
+		}
+		r := g.resolve(ref)
+		if r == nil {
+			return s
+		}
+		s = r
+	}
+	return s
+}
+
+// value generates a value satisfying the schema.
+func (g *generator) value(s schemajson.Schema, depth int) interface{} {
+	s = g.deref(s)
+	if s == nil {
+		return g.any(depth)
+	}
+	if depth > maxDepth {
+		return schemajson.Synthesize(s, g.resolve)
+	}
+	if enum := schemajson.Enum(s); len(enum) > 0 {
+		return enum[g.src.intn(len(enum))]
+	}
+	if c, ok := s["const"]; ok {
+		return c
+	}
+	for _, key := range []string{"anyOf", "oneOf"} {
+		if subs, ok := s[key].([]interface{}); ok && len(subs) > 0 {
+			return g.value(schemajson.FromValue(subs[g.src.intn(len(subs))]), depth+1)
+		}
+	}
+	if _, ok := s["allOf"]; ok {
+		// Generating for the intersection of schemas is out of scope.
+		return schemajson.Synthesize(s, g.resolve)
+	}
+
+	types := schemajson.Types(s)
+	if len(types) == 0 {
+		switch {
+		case s["properties"] != nil:
+			types = []string{"object"}
+		case s["items"] != nil:
+			types = []string{"array"}
+		default:
+			return g.any(depth)
+		}
+	}
+
+	switch types[g.src.intn(len(types))] {
+	case "null":
+		return nil
+	case "boolean":
+		return g.src.bool()
+	case "integer":
+		return g.number(s, true)
+	case "number":
+		return g.number(s, false)
+	case "string":
+		return g.string(s)
+	case "array":
+		min, max := bounds(s, "minItems", "maxItems")
+		n := g.length(min, max)
+		out := make([]interface{}, n)
+		items := schemajson.Items(s)
+		for i := range out {
+			out[i] = g.value(items, depth+1)
+		}
+		return out
+	case "object":
+		out := map[string]interface{}{}
+		required := map[string]bool{}
+		for _, r := range schemajson.Required(s) {
+			required[r] = true
+		}
+		props := schemajson.Properties(s)
+		for _, name := range schemajson.PropertyNames(s) {
+			// Optional properties are omitted at random, and always at the boundary.
+			if !required[name] && (g.boundary || g.src.bool()) {
+				continue
+			}
+			out[name] = g.value(props[name], depth+1)
+		}
+		return out
+	}
+	return nil
+}
+
+// any generates an arbitrary value for an unconstrained schema.
+func (g *generator) any(depth int) interface{} {
+	if depth > maxDepth {
+		return nil
+	}
+	switch g.src.intn(6) {
+	case 1:
+		return g.src.bool()
+	case 2:
+		return float64(int8(g.src.byte()))
+	case 3:
+		return g.string(nil)
+	case 4:
+		return []interface{}{g.any(depth + 1)}
+	case 5:
+		return map[string]interface{}{g.string(nil): g.any(depth + 1)}
+	}
+	return nil
+}
+
+// bounds returns the inclusive length bounds of the schema, capped at maxItems.
+func bounds(s schemajson.Schema, minKey, maxKey string) (int, int) {
+	min, max := 0, maxItems
+	if v, ok := schemajson.Number(s, minKey); ok {
+		min = int(v)
+	}
+	if v, ok := schemajson.Number(s, maxKey); ok && int(v) < max {
+		max = int(v)
+	}
+	if max < min {
+		max = min
+	}
+	return min, max
+}
+
+func (g *generator) length(min, max int) int {
+	if g.boundary {
+		if g.src.bool() {
+			return max
+		}
+		return min
+	}
+	return min + g.src.intn(max-min+1)
+}
+
+const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 _-.:/\"\\é世"
+
+func (g *generator) string(s schemajson.Schema) string {
+	if s != nil {
+		// Generating strings for patterns and formats is out of scope;
+		// fall back to the deterministic values.
+		if schemajson.String(s, "pattern") != "" || schemajson.String(s, "format") != "" {
+			return schemajson.Synthesize(s, g.resolve).(string)
+		}
+	}
+	min, max := bounds(s, "minLength", "maxLength")
+	runes := []rune(alphabet)
+	out := make([]rune, g.length(min, max))
+	for i := range out {
+		out[i] = runes[g.src.intn(len(runes))]
+	}
+	return string(out)
+}
+
+// boundaryNumbers are the extreme values tried when a schema doesn't bound a number.
+var boundaryNumbers = []float64{0, -1, 1, math.MaxInt32, math.MinInt32, 1 << 53, -(1 << 53)}
+
+func (g *generator) number(s schemajson.Schema, integer bool) float64 {
+	min, hasMin := schemajson.Number(s, "minimum")
+	max, hasMax := schemajson.Number(s, "maximum")
+	if v, ok := schemajson.Number(s, "exclusiveMinimum"); ok && (!hasMin || v >= min) {
+		min, hasMin = v+1, true
+	}
+	if v, ok := schemajson.Number(s, "exclusiveMaximum"); ok && (!hasMax || v <= max) {
+		max, hasMax = v-1, true
+	}
+
+	var f float64
+	if g.boundary {
+		candidates := []float64{}
+		if hasMin {
+			candidates = append(candidates, min)
+		}
+		if hasMax {
+			candidates = append(candidates, max)
+		}
+		for _, b := range boundaryNumbers {
+			if (!hasMin || b >= min) && (!hasMax || b <= max) {
+				candidates = append(candidates, b)
+			}
+		}
+		if len(candidates) == 0 {
+			candidates = append(candidates, min)
+		}
+		f = candidates[g.src.intn(len(candidates))]
+	} else {
+		f = float64(int16(uint16(g.src.byte())<<8 | uint16(g.src.byte())))
+		if !integer {
+			f += float64(g.src.byte()) / 256
+		}
+		if hasMin && f < min {
+			f = min
+		}
+		if hasMax && f > max {
+			f = max
+		}
+	}
+	if m, ok := schemajson.Number(s, "multipleOf"); ok && m > 0 {
+		f = math.Ceil(f/m) * m
+	}
+	if integer {
+		f = math.Ceil(f)
+	}
+	return f
+}
+
+// invalid generates a value which does not satisfy the schema, if it can;
+// unconstrained schemas are satisfied by any value.
+func (g *generator) invalid(s schemajson.Schema) interface{} {
+	s = g.deref(s)
+	candidates := []interface{}{}
+
+	// A value of a type the schema doesn't accept.
+	if types := schemajson.Types(s); len(types) > 0 {
+		for _, v := range []interface{}{nil, true, 1.5, float64(7), "x", []interface{}{}, map[string]interface{}{}} {
+			ok := false
+			for _, t := range types {
+				if schemajson.IsType(v, t) {
+					ok = true
+				}
+			}
+			if !ok {
+				candidates = append(candidates, v)
+			}
+		}
+	}
+	// A value just out of bounds.
+	if min, ok := schemajson.Number(s, "minimum"); ok {
+		candidates = append(candidates, min-1)
+	}
+	if max, ok := schemajson.Number(s, "maximum"); ok {
+		candidates = append(candidates, max+1)
+	}
+	if max, ok := schemajson.Number(s, "maxLength"); ok {
+		b := make([]byte, int(max)+1)
+		for i := range b {
+			b[i] = 'a'
+		}
+		candidates = append(candidates, string(b))
+	}
+	if len(schemajson.Enum(s)) > 0 {
+		candidates = append(candidates, "not-a-member-of-the-enum")
+	}
+	// An object missing a required property.
+	if req := schemajson.Required(s); len(req) > 0 {
+		if obj, ok := g.value(s, 0).(map[string]interface{}); ok {
+			delete(obj, req[g.src.intn(len(req))])
+			candidates = append(candidates, obj)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return g.value(s, 0)
+	}
+	return candidates[g.src.intn(len(candidates))]
+}
@@ -0,0 +1,216 @@
+// Package openrpcfuzz fuzzes the receivers registered with a Document,
+// calling their methods with params generated from the document's param schemas.
+//
+// Each call is checked for panics, and results are validated against the method's result schema.
+// Failures are reported with the method's document name, and the params it was called with.
+//
+// Fuzz integrates with Go's native fuzzing, so failing inputs are saved to the package's
+// testdata/fuzz corpus and replayed by later test runs:
+//
+//	func FuzzCalculator(f *testing.F) {
+//		d := &go_openrpc_reflect.Document{}
+//		d.WithMeta(meta).WithReflector(go_openrpc_reflect.EthereumReflector)
+//		d.RegisterReceiver(calculator)
+//		openrpcfuzz.Fuzz(f, d)
+//	}
+//
+// The document's reflector must implement go_openrpc_reflect.MethodInvoker,
+// as the standard and Ethereum reflectors do.
+package openrpcfuzz
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	go_openrpc_reflect "github.com/etclabscore/go-openrpc-reflect"
+	"github.com/etclabscore/go-openrpc-reflect/internal/schemajson"
+)
+
+// Target holds the methods of a document, ready to be called with generated params.
+type Target struct {
+	methods []*method
+	byName  map[string]*method
+}
+
+type method struct {
+	binding    go_openrpc_reflect.MethodBinding
+	paramTypes []reflect.Type
+	params     []schemajson.Schema
+	result     schemajson.Schema
+}
+
+// New returns a Target for the document's methods.
+func New(d *go_openrpc_reflect.Document) (*Target, error) {
+	bindings, err := d.Bindings()
+	if err != nil {
+		return nil, err
+	}
+	t := &Target{byName: map[string]*method{}}
+	for _, b := range bindings {
+		m := &method{binding: b}
+		if m.paramTypes, err = b.ParamTypes(); err != nil {
+			return nil, fmt.Errorf("method %s: %w", b.Name(), err)
+		}
+		if b.Method.Params != nil {
+			for _, p := range *b.Method.Params {
+				var s schemajson.Schema
+				if p.ContentDescriptorObject != nil {
+					if s, err = schemajson.FromJSONSchema(p.ContentDescriptorObject.Schema); err != nil {
+						return nil, fmt.Errorf("method %s: %w", b.Name(), err)
+					}
+				}
+				m.params = append(m.params, s)
+			}
+		}
+		if len(m.params) != len(m.paramTypes) {
+			return nil, fmt.Errorf("method %s: %d params documented for %d arguments", b.Name(), len(m.params), len(m.paramTypes))
+		}
+		if b.Method.Result != nil && b.Method.Result.ContentDescriptorObject != nil {
+			if m.result, err = schemajson.FromJSONSchema(b.Method.Result.ContentDescriptorObject.Schema); err != nil {
+				return nil, fmt.Errorf("method %s: %w", b.Name(), err)
+			}
+		}
+		t.methods = append(t.methods, m)
+		t.byName[b.Name()] = m
+	}
+	return t, nil
+}
+
+// Methods returns the document names of the target's methods.
+func (t *Target) Methods() []string {
+	out := make([]string, len(t.methods))
+	for i, m := range t.methods {
+		out[i] = m.binding.Name()
+	}
+	return out
+}
+
+// Failure describes a method call which panicked, or returned an invalid result.
+type Failure struct {
+	Method string
+	// Params is the JSON encoding of the params the method was called with.
+	Params string
+	// Panic is the value recovered from a panic, if the method panicked.
+	Panic interface{}
+	// Stack is the stack trace of the panic.
+	Stack string
+	// Errors are the result validation errors, if the result was invalid.
+	Errors []error
+}
+
+func (f *Failure) Error() string {
+	if f.Panic != nil {
+		return fmt.Sprintf("method %s panicked with params %s: %v\n%s", f.Method, f.Params, f.Panic, f.Stack)
+	}
+	errs := make([]string, len(f.Errors))
+	for i, e := range f.Errors {
+		errs[i] = e.Error()
+	}
+	return fmt.Sprintf("method %s returned a result not matching its schema with params %s: %s",
+		f.Method, f.Params, strings.Join(errs, "; "))
+}
+
+// Run calls a method with params generated from the data, returning a *Failure
+// if it panicked or returned an invalid result.
+// Unknown method names select a method by their hash, so that every input exercises some method.
+// Params which can't be decoded to the method's argument types are not called with,
+// as a JSON-RPC server would reject them; Run returns nil for those.
+func (t *Target) Run(name string, mode Mode, data []byte) error {
+	if len(t.methods) == 0 {
+		return nil
+	}
+	m, ok := t.byName[name]
+	if !ok {
+		h := fnv.New32a()
+		h.Write([]byte(name))
+		m = t.methods[h.Sum32()%uint32(len(t.methods))]
+	}
+
+	params := t.generate(m, mode%numModes, data)
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	args := make([]reflect.Value, len(params))
+	for i, p := range params {
+		b, _ := json.Marshal(p)
+		v := reflect.New(m.paramTypes[i])
+		if err := json.Unmarshal(b, v.Interface()); err != nil {
+			return nil
+		}
+		args[i] = v.Elem()
+	}
+	return t.call(m, args, string(paramsJSON))
+}
+
+func (t *Target) generate(m *method, mode Mode, data []byte) []interface{} {
+	g := &generator{src: &source{data: data}, boundary: mode == Boundary}
+	params := make([]interface{}, len(m.params))
+	invalid := -1
+	if mode == Invalid && len(params) > 0 {
+		invalid = g.src.intn(len(params))
+	}
+	for i, s := range m.params {
+		if i == invalid {
+			params[i] = g.invalid(s)
+			continue
+		}
+		params[i] = g.value(s, 0)
+	}
+	return params
+}
+
+func (t *Target) call(m *method, args []reflect.Value, params string) (err error) {
+	name := m.binding.Name()
+	defer func() {
+		if r := recover(); r != nil {
+			err = &Failure{Method: name, Params: params, Panic: r, Stack: string(debug.Stack())}
+		}
+	}()
+
+	result, callErr := m.binding.Call(args)
+	if callErr != nil {
+		// Methods are free to reject params.
+		return nil
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return &Failure{Method: name, Params: params, Errors: []error{fmt.Errorf("marshal result: %w", err)}}
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return &Failure{Method: name, Params: params, Errors: []error{fmt.Errorf("unmarshal result: %w", err)}}
+	}
+	// Discovered documents inline their schemas, so there are no references to resolve.
+	if errs := schemajson.Validate(m.result, v, nil); len(errs) > 0 {
+		return &Failure{Method: name, Params: params, Errors: errs}
+	}
+	return nil
+}
+
+// Fuzz fuzzes every method of the document's receivers.
+// The corpus is seeded with each method in each Mode.
+func Fuzz(f *testing.F, d *go_openrpc_reflect.Document) {
+	f.Helper()
+	t, err := New(d)
+	if err != nil {
+		f.Fatalf("openrpcfuzz: %v", err)
+	}
+	for _, name := range t.Methods() {
+		for mode := Mode(0); mode < numModes; mode++ {
+			f.Add(name, uint8(mode), []byte{})
+		}
+	}
+	f.Fuzz(func(tt *testing.T, name string, mode uint8, data []byte) {
+		if err := t.Run(name, Mode(mode), data); err != nil {
+			tt.Fatal(err)
+		}
+	})
+}
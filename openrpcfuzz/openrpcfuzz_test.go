@@ -0,0 +1,145 @@
+package openrpcfuzz
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	go_openrpc_reflect "github.com/etclabscore/go-openrpc-reflect"
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakeethereum"
+	meta_schema "github.com/open-rpc/meta-schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Numbers is a well behaved receiver.
+type Numbers struct{}
+
+// Sum returns the sum of the values.
+func (n *Numbers) Sum(values []int) (int, error) {
+	sum := 0
+	for _, v := range values {
+		sum += v
+	}
+	return sum, nil
+}
+
+// Div divides a by b.
+func (n *Numbers) Div(a, b int) (int, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a / b, nil
+}
+
+// Buggy is a badly behaved receiver.
+type Buggy struct{}
+
+// Div divides a by b.
+func (b *Buggy) Div(x, y int) int {
+	return x / y
+}
+
+// Version returns the version, whose JSON doesn't match the schema inferred from its zero value's.
+func (b *Buggy) Version() (Version, error) {
+	return 2, nil
+}
+
+// Version marshals as a number when zero, and as a string, eg. "v2", otherwise.
+type Version int
+
+// MarshalJSON implements the json Marshaler interface.
+func (v Version) MarshalJSON() ([]byte, error) {
+	if v == 0 {
+		return []byte("0"), nil
+	}
+	return json.Marshal(fmt.Sprintf("v%d", int(v)))
+}
+
+func newDocument(receiver interface{}) *go_openrpc_reflect.Document {
+	d := &go_openrpc_reflect.Document{}
+	d.WithMeta(&go_openrpc_reflect.MetaT{
+		GetServersFn: func() func(listeners []net.Listener) (*meta_schema.Servers, error) {
+			return func([]net.Listener) (*meta_schema.Servers, error) { return nil, nil }
+		},
+		GetInfoFn: func() (info *meta_schema.InfoObject) {
+			return &meta_schema.InfoObject{}
+		},
+		GetExternalDocsFn: func() (exdocs *meta_schema.ExternalDocumentationObject) {
+			return nil
+		},
+	})
+	d.WithReflector(go_openrpc_reflect.EthereumReflector)
+	d.RegisterReceiver(receiver)
+	return d
+}
+
+func FuzzNumbers(f *testing.F) {
+	Fuzz(f, newDocument(&Numbers{}))
+}
+
+func TestTarget_Run(t *testing.T) {
+	target, err := New(newDocument(&Buggy{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"buggy_div", "buggy_version"}, target.Methods())
+
+	// Boundary values include zero, which the first input byte selects for the divisor.
+	err = target.Run("buggy_div", Boundary, []byte{0, 0})
+	var failure *Failure
+	if assert.True(t, errors.As(err, &failure), "%v", err) {
+		assert.Equal(t, "buggy_div", failure.Method)
+		assert.Equal(t, "[0,0]", failure.Params)
+		assert.Contains(t, failure.Error(), "method buggy_div panicked with params [0,0]: runtime error: integer divide by zero")
+	}
+
+	err = target.Run("buggy_version", Conforming, nil)
+	if assert.True(t, errors.As(err, &failure), "%v", err) {
+		assert.Equal(t, "buggy_version", failure.Method)
+		assert.Nil(t, failure.Panic)
+		assert.True(t, strings.HasPrefix(failure.Error(), "method buggy_version returned a result not matching its schema with params []: "), failure.Error())
+	}
+}
+
+func TestNew_Subscriptions(t *testing.T) {
+	target, err := New(newDocument(&fakeethereum.Chain{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"chain_headerByNumber"}, target.Methods())
+}
+
+func TestTarget_Run_Modes(t *testing.T) {
+	target, err := New(newDocument(&Numbers{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs := [][]byte{nil, {1}, {0, 1, 2, 3}, {255, 254, 253, 252, 251, 250}, []byte("openrpc fuzz")}
+	for _, name := range append(target.Methods(), "unknown") {
+		for mode := Mode(0); mode < numModes; mode++ {
+			for _, in := range inputs {
+				assert.NoError(t, target.Run(name, mode, in), "%s %s %v", name, mode, in)
+			}
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	target, err := New(newDocument(&Numbers{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := target.byName["numbers_sum"]
+	// Conforming params validate; invalid params don't.
+	for _, in := range [][]byte{nil, {3, 1, 2, 3, 4, 5, 6, 7, 8}} {
+		params := target.generate(m, Conforming, in)
+		assert.Len(t, params, 1)
+		assert.IsType(t, []interface{}{}, params[0])
+	}
+	params := target.generate(m, Invalid, []byte{0, 0})
+	_, isArray := params[0].([]interface{})
+	assert.False(t, isArray, "%v", params[0])
+}
@@ -0,0 +1,250 @@
+package openrpcgen
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/schemajson"
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// Drift describes one difference between a source document and
+// the document discovered for its implementation.
+type Drift struct {
+	// Method is the name of the method the drift was found in,
+	// or empty for document-level drift.
+	Method string
+
+	// Path locates the drifting value within the method, eg. "params[0].schema.type".
+	Path string
+
+	Want string
+	Got  string
+}
+
+func (d Drift) String() string {
+	return fmt.Sprintf("%s: %s: want %s, got %s", d.Method, d.Path, d.Want, d.Got)
+}
+
+// Check compares a source document with the document discovered for an
+// implementation of the interfaces generated from it, eg. by Document.Discover.
+//
+// Only the values that reflection is able to reproduce are compared:
+// method names, param and result names, required and deprecated flags,
+// summaries, and the shape (types, properties, items, required properties and enums)
+// of schemas. Descriptions, which reflectors fill with the printed Go declaration, are not.
+func Check(source, discovered *meta_schema.OpenrpcDocument) []Drift {
+	c := &checker{}
+
+	want, got := methodsByName(source), methodsByName(discovered)
+	names := []string{}
+	for name := range want {
+		names = append(names, name)
+	}
+	for name := range got {
+		if _, ok := want[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		w, wok := want[name]
+		g, gok := got[name]
+		switch {
+		case !gok:
+			c.add(name, "", "method", "missing")
+		case !wok:
+			c.add(name, "", "no method", "method")
+		default:
+			c.method(name, w, g)
+		}
+	}
+	return c.drifts
+}
+
+type checker struct {
+	drifts []Drift
+}
+
+func (c *checker) add(method, path, want, got string) {
+	c.drifts = append(c.drifts, Drift{Method: method, Path: path, Want: want, Got: got})
+}
+
+func methodsByName(doc *meta_schema.OpenrpcDocument) map[string]meta_schema.MethodObject {
+	out := map[string]meta_schema.MethodObject{}
+	if doc == nil || doc.Methods == nil {
+		return out
+	}
+	for _, m := range *doc.Methods {
+		if m.Name != nil {
+			out[string(*m.Name)] = m
+		}
+	}
+	return out
+}
+
+func (c *checker) method(name string, want, got meta_schema.MethodObject) {
+	if ws, gs := summaryText(want), summaryText(got); ws != gs {
+		c.add(name, "summary", fmt.Sprintf("%q", ws), fmt.Sprintf("%q", gs))
+	}
+	if wd, gd := want.Deprecated != nil && bool(*want.Deprecated), got.Deprecated != nil && bool(*got.Deprecated); wd != gd {
+		c.add(name, "deprecated", fmt.Sprint(wd), fmt.Sprint(gd))
+	}
+	if want.ParamStructure != nil {
+		wp, gp := string(*want.ParamStructure), ""
+		if got.ParamStructure != nil {
+			gp = string(*got.ParamStructure)
+		}
+		if wp != gp {
+			c.add(name, "paramStructure", wp, gp)
+		}
+	}
+
+	wantParams, gotParams := paramObjects(want), paramObjects(got)
+	if len(wantParams) != len(gotParams) {
+		c.add(name, "params", fmt.Sprintf("%d params", len(wantParams)), fmt.Sprintf("%d params", len(gotParams)))
+	}
+	for i := 0; i < len(wantParams) && i < len(gotParams); i++ {
+		c.contentDescriptor(name, fmt.Sprintf("params[%d]", i), wantParams[i], gotParams[i])
+	}
+
+	var wr, gr *meta_schema.ContentDescriptorObject
+	if want.Result != nil {
+		wr = want.Result.ContentDescriptorObject
+	}
+	if got.Result != nil {
+		gr = got.Result.ContentDescriptorObject
+	}
+	switch {
+	case wr != nil && gr != nil:
+		c.contentDescriptor(name, "result", *wr, *gr)
+	case wr != nil:
+		c.add(name, "result", "result", "none")
+	case gr != nil:
+		c.add(name, "result", "no result", "result")
+	}
+}
+
+// summaryText normalizes a summary for comparison, ignoring deprecation notices,
+// which are generated into doc comments only to reproduce the deprecated flag.
+func summaryText(m meta_schema.MethodObject) string {
+	if m.Summary == nil {
+		return ""
+	}
+	lines := []string{}
+	for _, line := range strings.Split(string(*m.Summary), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "Deprecated:") {
+			continue
+		}
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func paramObjects(m meta_schema.MethodObject) []meta_schema.ContentDescriptorObject {
+	out := []meta_schema.ContentDescriptorObject{}
+	if m.Params == nil {
+		return out
+	}
+	for _, p := range *m.Params {
+		if p.ContentDescriptorObject != nil {
+			out = append(out, *p.ContentDescriptorObject)
+		} else {
+			out = append(out, meta_schema.ContentDescriptorObject{})
+		}
+	}
+	return out
+}
+
+func (c *checker) contentDescriptor(method, path string, want, got meta_schema.ContentDescriptorObject) {
+	if wn, gn := cdName(want), cdName(got); wn != gn {
+		c.add(method, path+".name", wn, gn)
+	}
+	// The spec defaults required to false.
+	wr := want.Required != nil && bool(*want.Required)
+	gr := got.Required != nil && bool(*got.Required)
+	if wr != gr {
+		c.add(method, path+".required", fmt.Sprint(wr), fmt.Sprint(gr))
+	}
+	ws, err := schemajson.FromJSONSchema(want.Schema)
+	if err != nil {
+		c.add(method, path+".schema", "valid schema", err.Error())
+		return
+	}
+	gs, err := schemajson.FromJSONSchema(got.Schema)
+	if err != nil {
+		c.add(method, path+".schema", "valid schema", err.Error())
+		return
+	}
+	c.schema(method, path+".schema", ws, gs)
+}
+
+func (c *checker) schema(method, path string, want, got schemajson.Schema) {
+	if want == nil {
+		return
+	}
+	if got == nil {
+		c.add(method, path, "schema", "none")
+		return
+	}
+	if wt := schemajson.Types(want); len(wt) > 0 {
+		if gt := schemajson.Types(got); !reflect.DeepEqual(wt, gt) {
+			c.add(method, path+".type", fmt.Sprint(wt), fmt.Sprint(gt))
+			return
+		}
+	}
+	if wr := schemajson.Required(want); len(wr) > 0 {
+		if gr := schemajson.Required(got); !sameSet(wr, gr) {
+			c.add(method, path+".required", fmt.Sprint(wr), fmt.Sprint(gr))
+		}
+	}
+	if we := schemajson.Enum(want); we != nil {
+		if ge := schemajson.Enum(got); !reflect.DeepEqual(we, ge) {
+			c.add(method, path+".enum", fmt.Sprint(we), fmt.Sprint(ge))
+		}
+	}
+	gotProps := schemajson.Properties(got)
+	for _, p := range schemajson.PropertyNames(want) {
+		gp, ok := gotProps[p]
+		if !ok {
+			c.add(method, path+".properties."+p, "property", "missing")
+			continue
+		}
+		c.schema(method, path+".properties."+p, schemajson.Properties(want)[p], gp)
+	}
+	wantProps := schemajson.Properties(want)
+	for _, p := range schemajson.PropertyNames(got) {
+		if _, ok := wantProps[p]; !ok && len(wantProps) > 0 {
+			c.add(method, path+".properties."+p, "no property", "property")
+		}
+	}
+	if wi := schemajson.Items(want); wi != nil {
+		c.schema(method, path+".items", wi, schemajson.Items(got))
+	}
+}
+
+// sameSet tells whether two lists hold the same strings,
+// regardless of order and repetition.
+func sameSet(a, b []string) bool {
+	as, bs := stringSet(a), stringSet(b)
+	if len(as) != len(bs) {
+		return false
+	}
+	for s := range as {
+		if !bs[s] {
+			return false
+		}
+	}
+	return true
+}
+
+func stringSet(strs []string) map[string]bool {
+	out := map[string]bool{}
+	for _, s := range strs {
+		out[s] = true
+	}
+	return out
+}
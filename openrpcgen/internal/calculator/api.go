@@ -0,0 +1,24 @@
+// Code generated by openrpcgen. DO NOT EDIT.
+
+package calculator
+
+// CalculatorServiceName is the module name under which implementations of CalculatorService
+// must be registered, eg. with Document.RegisterReceiverName.
+const CalculatorServiceName = "calculator"
+
+// CalculatorService is implemented by receivers serving the "calculator" module.
+type CalculatorService interface {
+	// Add adds two integers together.
+	Add(a int64, b int64) (sum int64, err error)
+	// History returns the operations done since the last reset.
+	History(limit int64) (items []HistoryItem, err error)
+	// Reset clears the calculator memory.
+	//
+	// Deprecated: this method is deprecated.
+	Reset() error
+}
+
+type HistoryItem struct {
+	Args   []int64 `json:"args"`
+	Method string  `json:"method"`
+}
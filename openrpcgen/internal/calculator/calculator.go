@@ -0,0 +1,34 @@
+// Package calculator holds an implementation of the interface generated
+// from ../../testdata/calculator.openrpc.json, used to test the generator round trip.
+package calculator
+
+//go:generate go run ../../../cmd/openrpc-reflect gen -style ethereum -package calculator -o api.go ../../testdata/calculator.openrpc.json
+
+// Calculator implements CalculatorService.
+type Calculator struct {
+	history []HistoryItem
+}
+
+var _ CalculatorService = (*Calculator)(nil)
+
+// Add adds two integers together.
+func (c *Calculator) Add(a int64, b int64) (sum int64, err error) {
+	c.history = append(c.history, HistoryItem{Method: "add", Args: []int64{a, b}})
+	return a + b, nil
+}
+
+// History returns the operations done since the last reset.
+func (c *Calculator) History(limit int64) (items []HistoryItem, err error) {
+	if limit <= 0 || int(limit) > len(c.history) {
+		return c.history, nil
+	}
+	return c.history[len(c.history)-int(limit):], nil
+}
+
+// Reset clears the calculator memory.
+//
+// Deprecated: this method is deprecated.
+func (c *Calculator) Reset() error {
+	c.history = nil
+	return nil
+}
@@ -0,0 +1,518 @@
+// Package openrpcgen generates Go service interfaces from an OpenRPC document.
+//
+// It is the inverse of the reflectors in the parent package: where those build
+// a document from Go receivers, this package builds Go declarations from a document,
+// such that reflecting an implementation of the generated interface with
+// the matching reflector reproduces the source document.
+// Use Check to report any drift between the two.
+package openrpcgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/schemajson"
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// Style selects the method signature convention of the generated interfaces.
+type Style int
+
+const (
+	// StyleStandard generates net/rpc style methods, to be reflected with StandardReflector:
+	//   func (t *T) MethodName(arg T1, reply *T2) error
+	StyleStandard Style = iota
+
+	// StyleEthereum generates go-ethereum/rpc style methods, to be reflected with EthereumReflector:
+	//   func (s *T) MethodName(a, b int) (int, error)
+	StyleEthereum
+)
+
+// Config configures Generate.
+type Config struct {
+	// Package is the name of the generated package. Defaults to "api".
+	Package string
+
+	// Style selects the method signature convention.
+	Style Style
+}
+
+var errMethodName = errors.New("method name does not follow style convention")
+var errParamCount = errors.New("net/rpc methods take exactly one argument")
+
+// Generate returns formatted Go source declaring one interface per module
+// (receiver) in the document, together with the request and response types
+// the interface methods use.
+func Generate(doc *meta_schema.OpenrpcDocument, cfg Config) ([]byte, error) {
+	if cfg.Package == "" {
+		cfg.Package = "api"
+	}
+	g := &generator{
+		style:   cfg.Style,
+		doc:     doc,
+		types:   map[string]string{},
+		structs: map[string]string{},
+	}
+
+	modules := []string{}
+	moduleMethods := map[string][]meta_schema.MethodObject{}
+	if doc.Methods != nil {
+		for _, m := range *doc.Methods {
+			if m.Name == nil {
+				continue
+			}
+			module, _, err := splitMethodName(cfg.Style, string(*m.Name))
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := moduleMethods[module]; !ok {
+				modules = append(modules, module)
+			}
+			moduleMethods[module] = append(moduleMethods[module], m)
+		}
+	}
+	sort.Strings(modules)
+
+	body := &bytes.Buffer{}
+	for _, module := range modules {
+		if err := g.module(body, module, moduleMethods[module]); err != nil {
+			return nil, err
+		}
+	}
+
+	out := &bytes.Buffer{}
+	fmt.Fprintf(out, "// Code generated by openrpcgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(out, "package %s\n\n", cfg.Package)
+	out.Write(body.Bytes())
+	for _, name := range g.typeOrder {
+		out.WriteString(g.types[name])
+		out.WriteString("\n")
+	}
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated source: %w\n%s", err, out.String())
+	}
+	return formatted, nil
+}
+
+// splitMethodName splits a method name into its module and method parts,
+// following the naming convention of the reflector matching the style.
+func splitMethodName(style Style, name string) (module, method string, err error) {
+	var i int
+	switch style {
+	case StyleStandard:
+		i = strings.LastIndex(name, ".")
+	case StyleEthereum:
+		i = strings.Index(name, "_")
+	}
+	if i <= 0 || i == len(name)-1 {
+		return "", "", fmt.Errorf("%w: %s", errMethodName, name)
+	}
+	return name[:i], name[i+1:], nil
+}
+
+type generator struct {
+	style Style
+	doc   *meta_schema.OpenrpcDocument
+
+	// types holds generated type declarations by name,
+	// and typeOrder the order in which they were first declared.
+	types     map[string]string
+	typeOrder []string
+
+	// structs maps struct bodies to the names they were declared with.
+	structs map[string]string
+}
+
+func (g *generator) module(w *bytes.Buffer, module string, methods []meta_schema.MethodObject) error {
+	iface := exportedIdent(module) + "Service"
+	fmt.Fprintf(w, "// %sName is the module name under which implementations of %s\n", iface, iface)
+	fmt.Fprintf(w, "// must be registered, eg. with Document.RegisterReceiverName.\n")
+	fmt.Fprintf(w, "const %sName = %q\n\n", iface, module)
+
+	fmt.Fprintf(w, "// %s is implemented by receivers serving the %q module.\n", iface, module)
+	fmt.Fprintf(w, "type %s interface {\n", iface)
+	for _, m := range methods {
+		if err := g.method(w, m); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(w, "}\n\n")
+	return nil
+}
+
+func (g *generator) method(w *bytes.Buffer, m meta_schema.MethodObject) error {
+	name := string(*m.Name)
+	_, methodPart, err := splitMethodName(g.style, name)
+	if err != nil {
+		return err
+	}
+	goName := exportedIdent(methodPart)
+
+	doc := ""
+	if m.Summary != nil {
+		doc = strings.TrimSpace(string(*m.Summary))
+	}
+	deprecated := m.Deprecated != nil && bool(*m.Deprecated)
+	if deprecated && !strings.Contains(strings.ToLower(doc), "deprecated") {
+		if doc != "" {
+			doc += "\n"
+		}
+		doc += "Deprecated: this method is deprecated."
+	}
+	if doc != "" {
+		// Go tooling recognizes a Deprecated: paragraph only after a blank comment line.
+		prev := ""
+		for _, line := range strings.Split(doc, "\n") {
+			line = strings.TrimRight(line, " \t")
+			if strings.HasPrefix(line, "Deprecated:") && prev != "" {
+				fmt.Fprint(w, "\t//\n")
+			}
+			if line == "" {
+				fmt.Fprint(w, "\t//\n")
+			} else {
+				fmt.Fprintf(w, "\t// %s\n", line)
+			}
+			prev = line
+		}
+	}
+
+	params := []meta_schema.ContentDescriptorObject{}
+	if m.Params != nil {
+		for _, p := range *m.Params {
+			cd, err := g.resolveContentDescriptor(p)
+			if err != nil {
+				return fmt.Errorf("method %s: %w", name, err)
+			}
+			params = append(params, cd)
+		}
+	}
+	var result *meta_schema.ContentDescriptorObject
+	if m.Result != nil {
+		result = m.Result.ContentDescriptorObject
+	}
+
+	switch g.style {
+	case StyleStandard:
+		return g.standardSignature(w, name, goName, params, result)
+	default:
+		return g.ethereumSignature(w, goName, params, result)
+	}
+}
+
+func (g *generator) resolveContentDescriptor(p meta_schema.ContentDescriptorOrReference) (meta_schema.ContentDescriptorObject, error) {
+	if p.ContentDescriptorObject != nil {
+		return *p.ContentDescriptorObject, nil
+	}
+	if p.ReferenceObject != nil && p.ReferenceObject.Ref != nil {
+		ref := string(*p.ReferenceObject.Ref)
+		const prefix = "#/components/contentDescriptors/"
+		if g.doc.Components != nil && g.doc.Components.ContentDescriptors != nil && strings.HasPrefix(ref, prefix) {
+			if v, ok := (*g.doc.Components.ContentDescriptors)[strings.TrimPrefix(ref, prefix)]; ok {
+				cd := meta_schema.ContentDescriptorObject{}
+				if err := remarshal(v, &cd); err != nil {
+					return cd, err
+				}
+				return cd, nil
+			}
+		}
+		return meta_schema.ContentDescriptorObject{}, fmt.Errorf("unresolved reference: %s", ref)
+	}
+	return meta_schema.ContentDescriptorObject{}, errors.New("empty content descriptor")
+}
+
+func (g *generator) standardSignature(w *bytes.Buffer, name, goName string, params []meta_schema.ContentDescriptorObject, result *meta_schema.ContentDescriptorObject) error {
+	if len(params) != 1 {
+		return fmt.Errorf("method %s: %w, document has %d params", name, errParamCount, len(params))
+	}
+	argName := paramIdent(cdName(params[0]), "arg")
+	argType, err := g.cdType(params[0], goName+"Arg")
+	if err != nil {
+		return err
+	}
+
+	replyName, replyType := "reply", "interface{}"
+	if result != nil {
+		replyName = paramIdent(cdName(*result), "reply")
+		if replyName == argName {
+			replyName = "reply"
+		}
+		replyType, err = g.cdType(*result, goName+"Reply")
+		if err != nil {
+			return err
+		}
+		// The reply is always passed by pointer; nullable results needn't be doubly so.
+		replyType = strings.TrimPrefix(replyType, "*")
+	}
+	fmt.Fprintf(w, "\t%s(%s %s, %s *%s) error\n", goName, argName, argType, replyName, replyType)
+	return nil
+}
+
+func (g *generator) ethereumSignature(w *bytes.Buffer, goName string, params []meta_schema.ContentDescriptorObject, result *meta_schema.ContentDescriptorObject) error {
+	// Go doesn't allow mixing named and unnamed parameters,
+	// so if any param name isn't a usable identifier, fall back to positional names.
+	named := true
+	for _, p := range params {
+		if !isUsableIdent(cdName(p)) {
+			named = false
+		}
+	}
+	ins := []string{}
+	seen := map[string]bool{}
+	for i, p := range params {
+		ty, err := g.cdType(p, goName+exportedIdent(cdName(p)))
+		if err != nil {
+			return err
+		}
+		n := cdName(p)
+		if !named || seen[n] {
+			n = fmt.Sprintf("arg%d", i)
+		}
+		seen[n] = true
+		ins = append(ins, n+" "+ty)
+	}
+
+	out := "error"
+	if result != nil && !isNullSchema(result.Schema) {
+		ty, err := g.cdType(*result, goName+"Result")
+		if err != nil {
+			return err
+		}
+		n := cdName(*result)
+		if isUsableIdent(n) && !seen[n] && !isPredeclared(n) {
+			out = fmt.Sprintf("(%s %s, err error)", n, ty)
+		} else {
+			out = fmt.Sprintf("(%s, error)", ty)
+		}
+	}
+	fmt.Fprintf(w, "\t%s(%s) %s\n", goName, strings.Join(ins, ", "), out)
+	return nil
+}
+
+func cdName(cd meta_schema.ContentDescriptorObject) string {
+	if cd.Name == nil {
+		return ""
+	}
+	return string(*cd.Name)
+}
+
+func (g *generator) cdType(cd meta_schema.ContentDescriptorObject, hint string) (string, error) {
+	s, err := schemajson.FromJSONSchema(cd.Schema)
+	if err != nil {
+		return "", err
+	}
+	return g.goType(s, hint), nil
+}
+
+func isNullSchema(s *meta_schema.JSONSchema) bool {
+	sch, err := schemajson.FromJSONSchema(s)
+	if err != nil || sch == nil {
+		return false
+	}
+	types := schemajson.Types(sch)
+	return len(types) == 1 && types[0] == "null"
+}
+
+// goType returns a Go type expression for the schema,
+// declaring any named types it needs along the way.
+// The hint names the type if the schema doesn't have a title.
+func (g *generator) goType(s schemajson.Schema, hint string) string {
+	if s == nil {
+		return "interface{}"
+	}
+	if ref := schemajson.String(s, "$ref"); ref != "" {
+		const prefix = "#/components/schemas/"
+		if g.doc.Components != nil && g.doc.Components.Schemas != nil && strings.HasPrefix(ref, prefix) {
+			name := strings.TrimPrefix(ref, prefix)
+			if v, ok := (*g.doc.Components.Schemas)[name]; ok {
+				return g.goType(schemajson.FromValue(v), exportedIdent(name))
+			}
+		}
+		return "interface{}"
+	}
+
+	if inner, ok := schemajson.NullableOf(s); ok {
+		ty := g.goType(inner, hint)
+		if strings.HasPrefix(ty, "*") || strings.HasPrefix(ty, "[]") || strings.HasPrefix(ty, "map[") || ty == "interface{}" {
+			return ty
+		}
+		return "*" + ty
+	}
+
+	types := schemajson.NonNullTypes(s)
+	nullable := schemajson.HasType(s, "null")
+	if len(types) == 0 && s["properties"] != nil {
+		types = []string{"object"}
+	}
+	if len(types) != 1 {
+		return "interface{}"
+	}
+
+	var ty string
+	switch types[0] {
+	case "string":
+		ty = "string"
+	case "integer":
+		ty = "int64"
+	case "number":
+		ty = "float64"
+	case "boolean":
+		ty = "bool"
+	case "array":
+		ty = "[]" + g.goType(schemajson.Items(s), hint+"Item")
+		return ty // Slices are already nilable.
+	case "object":
+		if len(schemajson.Properties(s)) == 0 {
+			valueType := "interface{}"
+			if ap := schemajson.FromValue(s["additionalProperties"]); ap != nil {
+				valueType = g.goType(ap, hint+"Value")
+			}
+			return "map[string]" + valueType
+		}
+		name := hint
+		if title := schemajson.String(s, "title"); title != "" {
+			name = exportedIdent(title)
+		}
+		ty = g.declareStruct(name, s)
+	default:
+		return "interface{}"
+	}
+	if nullable {
+		return "*" + ty
+	}
+	return ty
+}
+
+func (g *generator) declareStruct(name string, s schemajson.Schema) string {
+	props := schemajson.Properties(s)
+	names := schemajson.PropertyNames(s)
+	required := map[string]bool{}
+	for _, r := range schemajson.Required(s) {
+		required[r] = true
+	}
+	// The reflector considers all properties required unless told otherwise with
+	// jsonschema tags, so tags are only needed when some, but not all, are required.
+	tagRequired := len(required) > 0 && len(required) < len(props)
+
+	decl := &bytes.Buffer{}
+	fields := &bytes.Buffer{}
+	if desc := schemajson.String(s, "description"); desc != "" {
+		for _, line := range strings.Split(strings.TrimSpace(desc), "\n") {
+			fmt.Fprintf(decl, "// %s\n", line)
+		}
+	}
+	usedFields := map[string]bool{}
+	for _, p := range names {
+		field := exportedIdent(p)
+		for usedFields[field] {
+			field += "_"
+		}
+		usedFields[field] = true
+		ty := g.goType(props[p], name+field)
+		tag := fmt.Sprintf(`json:"%s"`, p)
+		if tagRequired {
+			if required[p] {
+				tag += ` jsonschema:"required"`
+			} else {
+				tag = fmt.Sprintf(`json:"%s,omitempty"`, p)
+			}
+		}
+		fmt.Fprintf(fields, "\t%s %s `%s`\n", field, ty, tag)
+	}
+	// Structurally identical types, eg. the same Go type used by several methods,
+	// are declared once.
+	key := decl.String() + fields.String()
+	if existing, ok := g.structs[key]; ok {
+		return existing
+	}
+	name = g.declare(name, func(typeName string) string {
+		return fmt.Sprintf("%stype %s struct {\n%s}\n", decl.String(), typeName, fields.String())
+	})
+	g.structs[key] = name
+	return name
+}
+
+// declare registers a type declaration under the given name, returning the
+// name actually used. A declaration with the same body as an existing one
+// reuses it; a conflicting one is given a numeric suffix.
+// The render function returns the declaration for a given type name.
+func (g *generator) declare(name string, render func(typeName string) string) string {
+	for i := 1; ; i++ {
+		candidate := name
+		if i > 1 {
+			candidate = fmt.Sprintf("%s%d", name, i)
+		}
+		existing, ok := g.types[candidate]
+		rendered := render(candidate)
+		if !ok {
+			g.types[candidate] = rendered
+			g.typeOrder = append(g.typeOrder, candidate)
+			return candidate
+		}
+		if existing == rendered {
+			return candidate
+		}
+	}
+}
+
+// exportedIdent converts an arbitrary name to an exported Go identifier,
+// eg. "total_calculator_use" -> "TotalCalculatorUse".
+func exportedIdent(s string) string {
+	out := []rune{}
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		out = append(out, r)
+	}
+	if len(out) == 0 || unicode.IsDigit(out[0]) {
+		out = append([]rune("X"), out...)
+	}
+	return string(out)
+}
+
+// paramIdent returns the name as-is if it is usable as a parameter name,
+// otherwise the fallback.
+func paramIdent(name, fallback string) string {
+	if isUsableIdent(name) {
+		return name
+	}
+	return fallback
+}
+
+func remarshal(in, out interface{}) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+func isUsableIdent(name string) bool {
+	return token.IsIdentifier(name) && name != "_"
+}
+
+func isPredeclared(name string) bool {
+	switch name {
+	case "bool", "byte", "complex64", "complex128", "error", "float32", "float64",
+		"int", "int8", "int16", "int32", "int64", "rune", "string",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		"any", "interface", "nil", "true", "false", "iota":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package openrpcgen
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	go_openrpc_reflect "github.com/etclabscore/go-openrpc-reflect"
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakearithmetic"
+	"github.com/etclabscore/go-openrpc-reflect/openrpcgen/internal/calculator"
+	meta_schema "github.com/open-rpc/meta-schema"
+	"github.com/stretchr/testify/assert"
+)
+
+var testMeta = &go_openrpc_reflect.MetaT{
+	GetServersFn: func() func(listeners []net.Listener) (*meta_schema.Servers, error) {
+		return func([]net.Listener) (*meta_schema.Servers, error) { return nil, nil }
+	},
+	GetInfoFn: func() (info *meta_schema.InfoObject) {
+		return nil
+	},
+	GetExternalDocsFn: func() (exdocs *meta_schema.ExternalDocumentationObject) {
+		return nil
+	},
+}
+
+func readTestDocument(t *testing.T, path string) *meta_schema.OpenrpcDocument {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := &meta_schema.OpenrpcDocument{}
+	if err := json.Unmarshal(b, doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func discover(t *testing.T, reflector go_openrpc_reflect.ReceiverRegisterer, name string, receiver interface{}) *meta_schema.OpenrpcDocument {
+	d := &go_openrpc_reflect.Document{}
+	d.WithMeta(testMeta).WithReflector(reflector)
+	d.RegisterReceiverName(name, receiver)
+	doc, err := d.Discover()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// TestGenerate_RoundTrip shows that the checked-in generated code is current,
+// and that reflecting an implementation of it reproduces the source document.
+func TestGenerate_RoundTrip(t *testing.T) {
+	source := readTestDocument(t, "testdata/calculator.openrpc.json")
+
+	src, err := Generate(source, Config{Package: "calculator", Style: StyleEthereum})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	checkedIn, err := ioutil.ReadFile("internal/calculator/api.go")
+	assert.NoError(t, err)
+	assert.Equal(t, string(checkedIn), string(src), "generated code is stale, run go generate ./...")
+
+	discovered := discover(t, go_openrpc_reflect.EthereumReflector, calculator.CalculatorServiceName, &calculator.Calculator{})
+	assert.Empty(t, Check(source, discovered))
+}
+
+func TestGenerate(t *testing.T) {
+	cases := []struct {
+		name      string
+		style     Style
+		reflector go_openrpc_reflect.ReceiverRegisterer
+		receiver  interface{}
+		contains  []string
+	}{
+		{
+			name:      "standard",
+			style:     StyleStandard,
+			reflector: go_openrpc_reflect.StandardReflector,
+			receiver:  &fakearithmetic.CalculatorRPC{},
+			contains: []string{
+				`const CalculatorRPCServiceName = "CalculatorRPC"`,
+				`Add(arg AddArg, reply *int64) error`,
+				`HasBatteries(arg string, reply *bool) error`,
+			},
+		},
+		{
+			name:      "ethereum",
+			style:     StyleEthereum,
+			reflector: go_openrpc_reflect.EthereumReflector,
+			receiver:  &fakearithmetic.Calculator{},
+			contains: []string{
+				`const CalculatorServiceName = "calculator"`,
+				`Add(argA int64, argB int64) (int64, error)`,
+				`Last() (calculation HistoryResultItem, err error)`,
+				`Reset() error`,
+				`GuessAreaOfCircle(arg0 map[string]interface{}, arg1 *ConstructCircleResult) (float64, error)`,
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			doc := discover(t, c.reflector, "", c.receiver)
+
+			src, err := Generate(doc, Config{Style: c.style})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			_, err = parser.ParseFile(token.NewFileSet(), "api.go", src, 0)
+			assert.NoError(t, err)
+			for _, want := range c.contains {
+				assert.Contains(t, string(src), want)
+			}
+		})
+	}
+}
+
+func TestGenerate_StandardParamCount(t *testing.T) {
+	doc := readTestDocument(t, "testdata/calculator.openrpc.json")
+	_, err := Generate(doc, Config{Style: StyleStandard})
+	assert.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	source := readTestDocument(t, "testdata/calculator.openrpc.json")
+	discovered := discover(t, go_openrpc_reflect.EthereumReflector, calculator.CalculatorServiceName, &calculator.Calculator{})
+
+	methods := *discovered.Methods
+	// Drop calculator_reset and rename a param of calculator_add.
+	methods = methods[:len(methods)-1]
+	renamed := "x"
+	methods[0].Params = &meta_schema.MethodObjectParams{
+		(*methods[0].Params)[1],
+		{ContentDescriptorObject: &meta_schema.ContentDescriptorObject{
+			Name:   (*meta_schema.ContentDescriptorObjectName)(&renamed),
+			Schema: (*methods[0].Params)[1].ContentDescriptorObject.Schema,
+		}},
+	}
+	discovered.Methods = (*meta_schema.Methods)(&methods)
+
+	drifts := Check(source, discovered)
+	got := []string{}
+	for _, d := range drifts {
+		got = append(got, d.String())
+	}
+	assert.ElementsMatch(t, []string{
+		"calculator_add: params[0].name: want a, got b",
+		"calculator_add: params[1].name: want b, got x",
+		"calculator_add: params[1].required: want true, got false",
+		"calculator_reset: : want method, got missing",
+	}, got)
+}
+
+func TestCheck_Result(t *testing.T) {
+	source := readTestDocument(t, "testdata/calculator.openrpc.json")
+	discovered := readTestDocument(t, "testdata/calculator.openrpc.json")
+
+	// Drop the result of calculator_add on one side and of
+	// calculator_history on the other.
+	methods := *discovered.Methods
+	methods[0].Result = nil
+	(*source.Methods)[1].Result = nil
+
+	got := []string{}
+	for _, d := range Check(source, discovered) {
+		got = append(got, d.String())
+	}
+	assert.ElementsMatch(t, []string{
+		"calculator_add: result: want result, got none",
+		"calculator_history: result: want no result, got result",
+	}, got)
+}
+
+func TestCheck_RequiredOrder(t *testing.T) {
+	schema := func(required string) *meta_schema.JSONSchema {
+		s := &meta_schema.JSONSchema{}
+		if err := json.Unmarshal([]byte(`{"type": "object", "required": `+required+`}`), s); err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	source := readTestDocument(t, "testdata/calculator.openrpc.json")
+	discovered := readTestDocument(t, "testdata/calculator.openrpc.json")
+	(*source.Methods)[0].Result.ContentDescriptorObject.Schema = schema(`["a", "b"]`)
+	(*discovered.Methods)[0].Result.ContentDescriptorObject.Schema = schema(`["b", "a", "b"]`)
+	assert.Empty(t, Check(source, discovered))
+
+	(*discovered.Methods)[0].Result.ContentDescriptorObject.Schema = schema(`["b"]`)
+	assert.Len(t, Check(source, discovered), 1)
+}
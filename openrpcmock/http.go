@@ -0,0 +1,139 @@
+package openrpcmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// JSON-RPC 2.0 error codes.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Error is a JSON-RPC 2.0 error object.
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Data != nil {
+		return fmt.Sprintf("%d %s: %v", e.Code, e.Message, e.Data)
+	}
+	return fmt.Sprintf("%d %s", e.Code, e.Message)
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type errorReply struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   *Error          `json:"error"`
+}
+
+type resultReply struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result"`
+}
+
+// ServeHTTP answers JSON-RPC 2.0 requests, including batches, POSTed to any path.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var out interface{}
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err != nil {
+			out = errorResponse(nil, &Error{Code: CodeParseError, Message: "Parse error", Data: err.Error()})
+		} else if len(batch) == 0 {
+			out = errorResponse(nil, &Error{Code: CodeInvalidRequest, Message: "Invalid Request", Data: "empty batch"})
+		} else {
+			responses := []interface{}{}
+			for _, raw := range batch {
+				if res := s.handle(raw); res != nil {
+					responses = append(responses, res)
+				}
+			}
+			if len(responses) > 0 {
+				out = responses
+			}
+		}
+	} else {
+		if res := s.handle(body); res != nil {
+			out = res
+		}
+	}
+
+	// Batches of notifications, and notifications, are not answered.
+	if out == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// handle answers a single request, returning nil for notifications.
+func (s *Server) handle(raw json.RawMessage) interface{} {
+	var req request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		if _, ok := err.(*json.SyntaxError); ok {
+			return errorResponse(nil, &Error{Code: CodeParseError, Message: "Parse error", Data: err.Error()})
+		}
+		return errorResponse(nil, &Error{Code: CodeInvalidRequest, Message: "Invalid Request", Data: err.Error()})
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, &Error{Code: CodeInvalidRequest, Message: "Invalid Request"})
+	}
+
+	result, rpcErr := s.dispatch(req)
+	if req.ID == nil {
+		return nil
+	}
+	if rpcErr != nil {
+		return errorResponse(req.ID, rpcErr)
+	}
+	return resultReply{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+func (s *Server) dispatch(req request) (interface{}, *Error) {
+	m, ok := s.methods[req.Method]
+	if !ok {
+		if req.Method == DiscoverMethod {
+			return s.doc, nil
+		}
+		return nil, &Error{Code: CodeMethodNotFound, Message: "Method not found", Data: req.Method}
+	}
+	return s.call(m, req.Params)
+}
+
+func errorResponse(id json.RawMessage, err *Error) errorReply {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return errorReply{JSONRPC: "2.0", ID: id, Error: err}
+}
@@ -0,0 +1,278 @@
+// Package openrpcmock serves a mock JSON-RPC 2.0 API over HTTP from an OpenRPC document,
+// so that clients can be developed and tested before the implementation exists.
+//
+// Each method answers with the result of the first of its examples whose params match
+// the request, or else with a value synthesized from its result schema.
+// Params are validated against the param schemas, and invalid params are rejected
+// with the JSON-RPC "Invalid params" error. The document itself is served by rpc.discover.
+//
+//	srv, err := openrpcmock.New(doc)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	ts := httptest.NewServer(srv)
+//	defer ts.Close()
+package openrpcmock
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/schemajson"
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// DiscoverMethod is the name of the service discovery method defined by the OpenRPC specification.
+const DiscoverMethod = "rpc.discover"
+
+// Server is a mock JSON-RPC 2.0 server for an OpenRPC document.
+// It implements http.Handler.
+type Server struct {
+	doc     *meta_schema.OpenrpcDocument
+	methods map[string]*method
+	resolve schemajson.Resolver
+}
+
+type method struct {
+	name   string
+	byName bool
+	params []param
+	// result is nil for methods without a result, which answer null.
+	result   schemajson.Schema
+	examples []example
+}
+
+type param struct {
+	name     string
+	required bool
+	schema   schemajson.Schema
+}
+
+type example struct {
+	// args holds the example's param values by position; absent params are not in present.
+	args    []interface{}
+	present []bool
+	result  interface{}
+}
+
+// New returns a mock server for the document.
+func New(doc *meta_schema.OpenrpcDocument) (*Server, error) {
+	s := &Server{
+		doc:     doc,
+		methods: map[string]*method{},
+		resolve: func(string) schemajson.Schema { return nil },
+	}
+	if doc.Components != nil && doc.Components.Schemas != nil {
+		s.resolve = schemajson.ComponentsResolver(*doc.Components.Schemas)
+	}
+	if doc.Methods == nil {
+		return s, nil
+	}
+	for _, mo := range *doc.Methods {
+		if mo.Name == nil {
+			continue
+		}
+		m, err := s.newMethod(mo)
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", *mo.Name, err)
+		}
+		s.methods[m.name] = m
+	}
+	return s, nil
+}
+
+func (s *Server) newMethod(mo meta_schema.MethodObject) (*method, error) {
+	m := &method{
+		name:   string(*mo.Name),
+		byName: mo.ParamStructure != nil && *mo.ParamStructure == "by-name",
+	}
+	if mo.Params != nil {
+		for i, p := range *mo.Params {
+			cd := p.ContentDescriptorObject
+			if cd == nil {
+				return nil, fmt.Errorf("param %d: references are not supported", i)
+			}
+			sch, err := schemajson.FromJSONSchema(cd.Schema)
+			if err != nil {
+				return nil, fmt.Errorf("param %d: %w", i, err)
+			}
+			m.params = append(m.params, param{
+				name:     cdName(cd),
+				required: cd.Required != nil && bool(*cd.Required),
+				schema:   sch,
+			})
+		}
+	}
+	if mo.Result != nil && mo.Result.ContentDescriptorObject != nil {
+		sch, err := schemajson.FromJSONSchema(mo.Result.ContentDescriptorObject.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("result: %w", err)
+		}
+		if t := schemajson.Types(sch); !(len(t) == 1 && t[0] == "null") {
+			m.result = sch
+		}
+	}
+	if mo.Examples != nil {
+		for _, ex := range *mo.Examples {
+			if ex.ExamplePairingObject == nil {
+				continue
+			}
+			e, err := m.newExample(ex.ExamplePairingObject)
+			if err != nil {
+				return nil, err
+			}
+			m.examples = append(m.examples, e)
+		}
+	}
+	return m, nil
+}
+
+func (m *method) newExample(ep *meta_schema.ExamplePairingObject) (example, error) {
+	e := example{
+		args:    make([]interface{}, len(m.params)),
+		present: make([]bool, len(m.params)),
+	}
+	if ep.Params != nil {
+		for j, p := range *ep.Params {
+			if p.ExampleObject == nil || p.ExampleObject.Value == nil {
+				continue
+			}
+			// Example params are matched to method params by name, falling back to position.
+			i := j
+			if p.ExampleObject.Name != nil {
+				if k := m.paramIndex(string(*p.ExampleObject.Name)); k >= 0 {
+					i = k
+				}
+			}
+			if i >= len(m.params) {
+				return e, fmt.Errorf("example has more params than the method")
+			}
+			v, err := normalize(*p.ExampleObject.Value)
+			if err != nil {
+				return e, err
+			}
+			e.args[i], e.present[i] = v, true
+		}
+	}
+	if ep.Result != nil && ep.Result.ExampleObject != nil && ep.Result.ExampleObject.Value != nil {
+		v, err := normalize(*ep.Result.ExampleObject.Value)
+		if err != nil {
+			return e, err
+		}
+		e.result = v
+	}
+	return e, nil
+}
+
+func (m *method) paramIndex(name string) int {
+	for i, p := range m.params {
+		if p.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// call answers a request for the method with the given raw params.
+func (s *Server) call(m *method, raw json.RawMessage) (interface{}, *Error) {
+	args, present, err := m.args(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []string{}
+	for i, p := range m.params {
+		if !present[i] {
+			if p.required {
+				problems = append(problems, fmt.Sprintf("%s: missing required param", p.name))
+			}
+			continue
+		}
+		for _, e := range schemajson.Validate(p.schema, args[i], s.resolve) {
+			problems = append(problems, prefixPath(p.name, e))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &Error{Code: CodeInvalidParams, Message: "Invalid params", Data: problems}
+	}
+
+	for _, e := range m.examples {
+		if reflect.DeepEqual(e.present, present) && reflect.DeepEqual(e.args, args) {
+			return e.result, nil
+		}
+	}
+	if m.result == nil {
+		return nil, nil
+	}
+	return schemajson.Synthesize(m.result, s.resolve), nil
+}
+
+// args decodes the request params to their positions in the method's params.
+func (m *method) args(raw json.RawMessage) ([]interface{}, []bool, *Error) {
+	args := make([]interface{}, len(m.params))
+	present := make([]bool, len(m.params))
+
+	var v interface{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, nil, &Error{Code: CodeInvalidParams, Message: "Invalid params", Data: err.Error()}
+		}
+	}
+
+	switch vv := v.(type) {
+	case nil:
+	case []interface{}:
+		if m.byName {
+			return nil, nil, &Error{Code: CodeInvalidParams, Message: "Invalid params", Data: "params must be passed by name"}
+		}
+		if len(vv) > len(m.params) {
+			return nil, nil, &Error{Code: CodeInvalidParams, Message: "Invalid params",
+				Data: fmt.Sprintf("too many params: want at most %d, got %d", len(m.params), len(vv))}
+		}
+		for i, a := range vv {
+			args[i], present[i] = a, true
+		}
+	case map[string]interface{}:
+		for name, a := range vv {
+			i := m.paramIndex(name)
+			if i < 0 {
+				return nil, nil, &Error{Code: CodeInvalidParams, Message: "Invalid params", Data: fmt.Sprintf("unknown param %q", name)}
+			}
+			args[i], present[i] = a, true
+		}
+	default:
+		return nil, nil, &Error{Code: CodeInvalidRequest, Message: "Invalid Request", Data: "params must be an array or an object"}
+	}
+	return args, present, nil
+}
+
+// normalize round-trips a value through JSON, so that it compares equal
+// to the same value decoded from a request.
+func normalize(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	err = json.Unmarshal(b, &out)
+	return out, err
+}
+
+func prefixPath(name string, err error) string {
+	if ve, ok := err.(*schemajson.ValidationError); ok && ve.Path != "" {
+		if strings.HasPrefix(ve.Path, "[") {
+			return name + ve.Path + ": " + ve.Message
+		}
+		return name + "." + ve.Path + ": " + ve.Message
+	}
+	return name + ": " + err.Error()
+}
+
+func cdName(cd *meta_schema.ContentDescriptorObject) string {
+	if cd.Name == nil {
+		return ""
+	}
+	return string(*cd.Name)
+}
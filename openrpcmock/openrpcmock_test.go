@@ -0,0 +1,176 @@
+package openrpcmock
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	go_openrpc_reflect "github.com/etclabscore/go-openrpc-reflect"
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakearithmetic"
+	meta_schema "github.com/open-rpc/meta-schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	b, err := ioutil.ReadFile("testdata/pets.openrpc.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := &meta_schema.OpenrpcDocument{}
+	if err := json.Unmarshal(b, doc); err != nil {
+		t.Fatal(err)
+	}
+	srv, err := New(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return httptest.NewServer(srv)
+}
+
+func post(t *testing.T, url, body string) (int, string) {
+	t.Helper()
+	res, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.StatusCode, strings.TrimSpace(string(b))
+}
+
+func TestServer(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+
+	cases := []struct {
+		name    string
+		request string
+		expect  string
+	}{
+		{
+			name:    "example",
+			request: `{"jsonrpc":"2.0","id":1,"method":"pets_get","params":[1]}`,
+			expect:  `{"jsonrpc":"2.0","id":1,"result":{"id":1,"kind":"dog","name":"Rex","tags":["good"]}}`,
+		},
+		{
+			name:    "synthesized",
+			request: `{"jsonrpc":"2.0","id":"a","method":"pets_get","params":[2]}`,
+			expect:  `{"jsonrpc":"2.0","id":"a","result":{"born":"1970-01-01T00:00:00Z","id":1,"kind":"cat","name":"a","tags":[]}}`,
+		},
+		{
+			name:    "null result",
+			request: `{"jsonrpc":"2.0","id":2,"method":"pets_put","params":{"pet":{"id":3,"name":"Tom","kind":"cat"}}}`,
+			expect:  `{"jsonrpc":"2.0","id":2,"result":null}`,
+		},
+		{
+			name:    "invalid params",
+			request: `{"jsonrpc":"2.0","id":3,"method":"pets_put","params":{"pet":{"id":0,"kind":"cow","color":"red"},"overwrite":"yes"}}`,
+			expect: `{"jsonrpc":"2.0","id":3,"error":{"code":-32602,"message":"Invalid params","data":[` +
+				`"pet: missing required property \"name\"",` +
+				`"pet.color: unknown property",` +
+				`"pet.id: value 0 is less than the minimum 1",` +
+				`"pet.kind: value must be one of [cat dog]",` +
+				`"overwrite: expected boolean, got string"]}}`,
+		},
+		{
+			name:    "missing param",
+			request: `{"jsonrpc":"2.0","id":4,"method":"pets_get","params":[]}`,
+			expect:  `{"jsonrpc":"2.0","id":4,"error":{"code":-32602,"message":"Invalid params","data":["id: missing required param"]}}`,
+		},
+		{
+			name:    "by-name only",
+			request: `{"jsonrpc":"2.0","id":5,"method":"pets_put","params":[{}]}`,
+			expect:  `{"jsonrpc":"2.0","id":5,"error":{"code":-32602,"message":"Invalid params","data":"params must be passed by name"}}`,
+		},
+		{
+			name:    "method not found",
+			request: `{"jsonrpc":"2.0","id":6,"method":"pets_delete"}`,
+			expect:  `{"jsonrpc":"2.0","id":6,"error":{"code":-32601,"message":"Method not found","data":"pets_delete"}}`,
+		},
+		{
+			name:    "parse error",
+			request: `{"jsonrpc":`,
+			expect:  `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"Parse error","data":"unexpected end of JSON input"}}`,
+		},
+		{
+			name: "batch",
+			request: `[{"jsonrpc":"2.0","id":1,"method":"pets_get","params":[1]},` +
+				`{"jsonrpc":"2.0","method":"pets_get","params":[1]},` +
+				`{"jsonrpc":"1.0","id":2,"method":"pets_get"}]`,
+			expect: `[{"jsonrpc":"2.0","id":1,"result":{"id":1,"kind":"dog","name":"Rex","tags":["good"]}},` +
+				`{"jsonrpc":"2.0","id":2,"error":{"code":-32600,"message":"Invalid Request"}}]`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			code, body := post(t, ts.URL, c.request)
+			assert.Equal(t, http.StatusOK, code)
+			assert.Equal(t, c.expect, body)
+		})
+	}
+
+	t.Run("notification", func(t *testing.T) {
+		code, body := post(t, ts.URL, `{"jsonrpc":"2.0","method":"pets_get","params":[1]}`)
+		assert.Equal(t, http.StatusNoContent, code)
+		assert.Empty(t, body)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		res, err := http.Get(ts.URL)
+		assert.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+	})
+}
+
+// TestServer_Discovered mocks a document discovered from a receiver.
+func TestServer_Discovered(t *testing.T) {
+	d := &go_openrpc_reflect.Document{}
+	d.WithMeta(&go_openrpc_reflect.MetaT{
+		GetServersFn: func() func(listeners []net.Listener) (*meta_schema.Servers, error) {
+			return func([]net.Listener) (*meta_schema.Servers, error) { return nil, nil }
+		},
+		GetInfoFn: func() (info *meta_schema.InfoObject) {
+			title, version := "Calculator API", "1.0.0"
+			return &meta_schema.InfoObject{
+				Title:   (*meta_schema.InfoObjectProperties)(&title),
+				Version: (*meta_schema.InfoObjectVersion)(&version),
+			}
+		},
+		GetExternalDocsFn: func() (exdocs *meta_schema.ExternalDocumentationObject) {
+			return nil
+		},
+	})
+	d.WithReflector(go_openrpc_reflect.EthereumReflector)
+	d.RegisterReceiver(&fakearithmetic.Calculator{})
+	doc, err := d.Discover()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := New(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	_, body := post(t, ts.URL, `{"jsonrpc":"2.0","id":1,"method":"calculator_add","params":[1,2]}`)
+	assert.Equal(t, `{"jsonrpc":"2.0","id":1,"result":0}`, body)
+
+	_, body = post(t, ts.URL, `{"jsonrpc":"2.0","id":1,"method":"calculator_add","params":[1.5,2]}`)
+	assert.Equal(t, `{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"Invalid params","data":["argA: expected integer, got number"]}}`, body)
+
+	_, body = post(t, ts.URL, `{"jsonrpc":"2.0","id":1,"method":"rpc.discover"}`)
+	var got struct {
+		Result meta_schema.OpenrpcDocument `json:"result"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(body), &got))
+	assert.Len(t, *got.Result.Methods, len(*doc.Methods))
+}
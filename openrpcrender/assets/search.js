@@ -0,0 +1,16 @@
+// Filters the method index and sections by the text typed in the search box.
+(function () {
+  var input = document.getElementById("search");
+  if (!input) {
+    return;
+  }
+  input.addEventListener("input", function () {
+    var q = input.value.trim().toLowerCase();
+    var items = document.querySelectorAll("[data-search]");
+    for (var i = 0; i < items.length; i++) {
+      var el = items[i];
+      var match = q === "" || el.getAttribute("data-search").indexOf(q) !== -1;
+      el.classList.toggle("hidden", !match);
+    }
+  });
+})();
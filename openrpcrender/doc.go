@@ -0,0 +1,11 @@
+// Package openrpcrender renders OpenRPC documents, eg. those returned by Document.Discover,
+// as human readable documentation.
+//
+// Markdown renders a single Markdown file, and MarkdownByTag one file per method tag.
+// HTML renders a self-contained page with a searchable method index; all of its
+// assets are embedded, so the output needs no network access to be viewed.
+//
+// Both formats include schema tables with nested properties flattened into rows,
+// method examples rendered as JSON-RPC request/response pairs with curl snippets,
+// and deprecation banners.
+package openrpcrender
@@ -0,0 +1,164 @@
+package openrpcrender
+
+import (
+	"embed"
+	"encoding/json"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+//go:embed assets
+var assets embed.FS
+
+func mustAsset(name string) string {
+	b, err := assets.ReadFile("assets/" + name)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+var htmlFuncs = template.FuncMap{
+	"text":   renderText,
+	"indent": func(depth int) template.HTML { return template.HTML(strings.Repeat("&nbsp;&nbsp;&nbsp;&nbsp;", depth)) },
+	"search": func(m method) string {
+		return strings.ToLower(strings.Join(append([]string{m.Name, m.Summary}, m.Tags...), " "))
+	},
+	"css": func() template.CSS { return template.CSS(mustAsset("style.css")) },
+	"js":  func() template.JS { return template.JS(mustAsset("search.js")) },
+}
+
+var htmlTemplate = template.Must(template.New("html").Funcs(htmlFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ .Title }}</title>
+<style>{{ css }}</style>
+</head>
+<body>
+<nav>
+<h1>{{ .Title }}</h1>
+{{ if .Version }}<div class="version">Version {{ .Version }}</div>{{ end }}
+<input id="search" type="search" placeholder="Search methods" autocomplete="off">
+<ul>
+{{ range .Methods }}<li data-search="{{ search . }}"{{ if .Deprecated }} class="deprecated"{{ end }}><a href="#{{ .Anchor }}">{{ .Name }}</a></li>
+{{ end }}</ul>
+</nav>
+<main>
+<h1>{{ .Title }}</h1>
+{{ if .Description }}{{ text .Description }}{{ end }}
+{{ if .Servers }}<h2>Servers</h2>
+<table><tr><th>Name</th><th>URL</th><th>Description</th></tr>
+{{ range .Servers }}<tr><td>{{ .Name }}</td><td><code>{{ .URL }}</code></td><td>{{ .Description }}</td></tr>
+{{ end }}</table>{{ end }}
+{{ range .Methods }}{{ template "method" . }}{{ end }}
+</main>
+<script>{{ js }}</script>
+</body>
+</html>
+`))
+
+func init() {
+	template.Must(htmlTemplate.New("method").Parse(`<section class="method" id="{{ .Anchor }}" data-search="{{ search . }}">
+<h2>{{ .Name }}</h2>
+{{ if .Deprecated }}<div class="banner"><strong>Deprecated:</strong> this method is deprecated and may be removed.</div>{{ end }}
+{{ if .Summary }}<div class="summary">{{ text .Summary }}</div>{{ end }}
+{{ if .Tags }}<div>{{ range .Tags }}<span class="tag">{{ . }}</span>{{ end }}</div>{{ end }}
+{{ if .Description }}{{ text .Description }}{{ end }}
+<h3>Params</h3>
+{{ if .Params }}{{ template "table" .Params }}{{ else }}<p><em>None.</em></p>{{ end }}
+<h3>Result</h3>
+{{ if .Result }}{{ template "table" .Result }}{{ else }}<p><em>None.</em></p>{{ end }}
+{{ if .Errors }}<h3>Errors</h3>
+<table><tr><th>Code</th><th>Message</th></tr>
+{{ range .Errors }}<tr><td>{{ .Code }}</td><td>{{ .Message }}</td></tr>
+{{ end }}</table>{{ end }}
+{{ range .Examples }}<h3>{{ .Name }}</h3>
+<div class="examples">
+<div><h4>Request</h4><pre><code>{{ .Request }}</code></pre></div>
+<div><h4>Response</h4><pre><code>{{ .Response }}</code></pre></div>
+<div class="curl"><pre><code>{{ .Curl }}</code></pre></div>
+</div>
+{{ end }}</section>
+`))
+
+	template.Must(htmlTemplate.New("table").Parse(`<table><tr><th>Name</th><th>Type</th><th>Required</th><th>Description</th></tr>
+{{ range . }}<tr><td>{{ indent .Depth }}<code>{{ .Path }}</code>{{ if .Deprecated }} <em>(deprecated)</em>{{ end }}</td><td>{{ .Type }}</td><td>{{ if .Required }}yes{{ else }}no{{ end }}</td><td>{{ text .Description }}</td></tr>
+{{ end }}</table>
+`))
+}
+
+// HTML renders the document as a single self-contained HTML page, with a searchable
+// method index. Styles and scripts are inlined; the page makes no network requests.
+func HTML(w io.Writer, doc *meta_schema.OpenrpcDocument, opts Options) error {
+	p, err := newPage(doc, opts)
+	if err != nil {
+		return err
+	}
+	return htmlTemplate.Execute(w, p)
+}
+
+// renderText renders the small subset of Markdown that reflected documents use:
+// fenced code blocks (eg. the printed Go declarations in method descriptions)
+// and blank-line separated paragraphs. Everything else is escaped verbatim.
+func renderText(s string) template.HTML {
+	var b strings.Builder
+	para := []string{}
+	flush := func() {
+		if len(para) > 0 {
+			b.WriteString("<p>" + template.HTMLEscapeString(strings.Join(para, "\n")) + "</p>\n")
+			para = nil
+		}
+	}
+	lines := strings.Split(s, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			flush()
+			code := []string{}
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			b.WriteString("<pre><code>" + template.HTMLEscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		para = append(para, line)
+	}
+	flush()
+	return template.HTML(b.String())
+}
+
+// WriteSite writes a static documentation site to dir: the rendered index.html,
+// and the document itself as openrpc.json.
+func WriteSite(dir string, doc *meta_schema.OpenrpcDocument, opts Options) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(dir, "index.html"))
+	if err != nil {
+		return err
+	}
+	if err := HTML(f, doc, opts); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "openrpc.json"), b, 0644)
+}
@@ -0,0 +1,150 @@
+package openrpcrender
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// UntaggedFile is the file name MarkdownByTag uses for methods without tags.
+const UntaggedFile = "untagged.md"
+
+var markdownFuncs = template.FuncMap{
+	"cell": func(s string) string {
+		s = strings.ReplaceAll(s, "|", `\|`)
+		return strings.ReplaceAll(strings.TrimSpace(s), "\n", "<br>")
+	},
+	"indent": func(depth int) string {
+		return strings.Repeat("&nbsp;&nbsp;", depth)
+	},
+	"quote": func(s string) string {
+		return "> " + strings.ReplaceAll(s, "\n", "\n> ")
+	},
+}
+
+var markdownTemplate = template.Must(template.New("markdown").Funcs(markdownFuncs).Parse(`# {{ .Title }}
+{{ if .Version }}
+Version: {{ .Version }}
+{{ end }}{{ if .Description }}
+{{ .Description }}
+{{ end }}{{ if .Servers }}
+## Servers
+
+| Name | URL | Description |
+|------|-----|-------------|
+{{ range .Servers }}| {{ cell .Name }} | {{ cell .URL }} | {{ cell .Description }} |
+{{ end }}{{ end }}
+## Methods
+{{ range .Methods }}
+- [{{ .Name }}](#{{ .Anchor }}){{ if .Deprecated }} _(deprecated)_{{ end }}{{ end }}
+{{ range .Methods }}
+{{ template "method" . }}{{ end }}`))
+
+func init() {
+	template.Must(markdownTemplate.New("method").Parse(`---
+
+### {{ .Name }}
+{{ if .Deprecated }}
+> **Deprecated**: this method is deprecated and may be removed.
+{{ end }}{{ if .Summary }}
+{{ quote .Summary }}
+{{ end }}{{ if .Tags }}
+Tags: {{ range $i, $t := .Tags }}{{ if $i }}, {{ end }}` + "`{{ $t }}`" + `{{ end }}
+{{ end }}{{ if .Description }}
+{{ .Description }}
+{{ end }}
+#### Params
+{{ if .Params }}
+{{ template "table" .Params }}{{ else }}
+_None._
+{{ end }}
+#### Result
+{{ if .Result }}
+{{ template "table" .Result }}{{ else }}
+_None._
+{{ end }}{{ if .Errors }}
+#### Errors
+
+| Code | Message |
+|------|---------|
+{{ range .Errors }}| {{ .Code }} | {{ cell .Message }} |
+{{ end }}{{ end }}{{ range .Examples }}
+#### {{ .Name }}
+
+Request:
+
+` + "```json" + `
+{{ .Request }}
+` + "```" + `
+
+Response:
+
+` + "```json" + `
+{{ .Response }}
+` + "```" + `
+
+` + "```sh" + `
+{{ .Curl }}
+` + "```" + `
+{{ end }}`))
+
+	template.Must(markdownTemplate.New("table").Parse(`| Name | Type | Required | Description |
+|------|------|----------|-------------|
+{{ range . }}| {{ indent .Depth }}` + "`{{ .Path }}`" + `{{ if .Deprecated }} _(deprecated)_{{ end }} | {{ cell .Type }} | {{ if .Required }}yes{{ else }}no{{ end }} | {{ cell .Description }} |
+{{ end }}`))
+}
+
+// Markdown renders the document as a single Markdown file.
+func Markdown(w io.Writer, doc *meta_schema.OpenrpcDocument, opts Options) error {
+	p, err := newPage(doc, opts)
+	if err != nil {
+		return err
+	}
+	return markdownTemplate.Execute(w, p)
+}
+
+// MarkdownByTag renders the document as one Markdown file per method tag,
+// keyed by file name (eg. "accounts.md"). Methods with several tags appear in each
+// of their files; untagged methods are rendered to UntaggedFile.
+func MarkdownByTag(doc *meta_schema.OpenrpcDocument, opts Options) (map[string][]byte, error) {
+	p, err := newPage(doc, opts)
+	if err != nil {
+		return nil, err
+	}
+	byTag := map[string][]method{}
+	for _, m := range p.Methods {
+		if len(m.Tags) == 0 {
+			byTag[""] = append(byTag[""], m)
+			continue
+		}
+		for _, t := range m.Tags {
+			byTag[t] = append(byTag[t], m)
+		}
+	}
+	tags := []string{}
+	for t := range byTag {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+
+	out := map[string][]byte{}
+	for _, t := range tags {
+		tp := *p
+		tp.Methods = byTag[t]
+		file := UntaggedFile
+		if t != "" {
+			tp.Title = p.Title + ": " + t
+			file = anchor(t) + ".md"
+		}
+		buf := &bytes.Buffer{}
+		if err := markdownTemplate.Execute(buf, &tp); err != nil {
+			return nil, err
+		}
+		out[file] = buf.Bytes()
+	}
+	return out, nil
+}
@@ -0,0 +1,362 @@
+package openrpcrender
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/schemajson"
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// Options configures rendering.
+type Options struct {
+	// ServerURL is used as the endpoint in curl snippets.
+	// Defaults to the URL of the document's first server, or http://localhost:8545.
+	ServerURL string
+}
+
+// page is the view model shared by the Markdown and HTML templates.
+type page struct {
+	Title       string
+	Version     string
+	Description string
+	ServerURL   string
+	Servers     []server
+	Methods     []method
+}
+
+type server struct {
+	Name, URL, Description string
+}
+
+type method struct {
+	Name        string
+	Anchor      string
+	Summary     string
+	Description string
+	Deprecated  bool
+	Tags        []string
+	Params      []schemaRow
+	Result      []schemaRow
+	Errors      []errorRow
+	Examples    []example
+}
+
+// schemaRow is one row of a schema table.
+// Nested properties are flattened into rows with dotted paths, eg. "arg.a",
+// and array items are denoted by [], eg. "items[].method".
+type schemaRow struct {
+	Path        string
+	Depth       int
+	Type        string
+	Required    bool
+	Deprecated  bool
+	Description string
+}
+
+type errorRow struct {
+	Code    int64
+	Message string
+}
+
+type example struct {
+	Name     string
+	Request  string
+	Response string
+	Curl     string
+}
+
+func newPage(doc *meta_schema.OpenrpcDocument, opts Options) (*page, error) {
+	p := &page{}
+	if doc.Info != nil {
+		if doc.Info.Title != nil {
+			p.Title = string(*doc.Info.Title)
+		}
+		if doc.Info.Version != nil {
+			p.Version = string(*doc.Info.Version)
+		}
+		if doc.Info.Description != nil {
+			p.Description = string(*doc.Info.Description)
+		}
+	}
+	if p.Title == "" {
+		p.Title = "OpenRPC API"
+	}
+	if doc.Servers != nil {
+		for _, s := range *doc.Servers {
+			srv := server{}
+			if s.Name != nil {
+				srv.Name = string(*s.Name)
+			}
+			if s.Url != nil {
+				srv.URL = string(*s.Url)
+			}
+			if s.Description != nil {
+				srv.Description = string(*s.Description)
+			}
+			p.Servers = append(p.Servers, srv)
+		}
+	}
+	p.ServerURL = opts.ServerURL
+	if p.ServerURL == "" && len(p.Servers) > 0 {
+		p.ServerURL = p.Servers[0].URL
+	}
+	if p.ServerURL == "" {
+		p.ServerURL = "http://localhost:8545"
+	}
+
+	if doc.Methods != nil {
+		for _, m := range *doc.Methods {
+			vm, err := newMethod(m, p.ServerURL)
+			if err != nil {
+				return nil, err
+			}
+			p.Methods = append(p.Methods, vm)
+		}
+	}
+	sort.Slice(p.Methods, func(i, j int) bool {
+		return p.Methods[i].Name < p.Methods[j].Name
+	})
+	return p, nil
+}
+
+func newMethod(m meta_schema.MethodObject, serverURL string) (method, error) {
+	vm := method{}
+	if m.Name != nil {
+		vm.Name = string(*m.Name)
+	}
+	vm.Anchor = anchor(vm.Name)
+	if m.Summary != nil {
+		vm.Summary = strings.TrimSpace(string(*m.Summary))
+	}
+	if m.Description != nil {
+		vm.Description = strings.TrimSpace(string(*m.Description))
+	}
+	vm.Deprecated = m.Deprecated != nil && bool(*m.Deprecated)
+	if m.Tags != nil {
+		for _, t := range *m.Tags {
+			if t.TagObject != nil && t.TagObject.Name != nil {
+				vm.Tags = append(vm.Tags, string(*t.TagObject.Name))
+			}
+		}
+	}
+
+	paramNames := []string{}
+	if m.Params != nil {
+		for _, p := range *m.Params {
+			if p.ContentDescriptorObject == nil {
+				continue
+			}
+			rows, err := contentDescriptorRows(*p.ContentDescriptorObject)
+			if err != nil {
+				return vm, fmt.Errorf("method %s: %w", vm.Name, err)
+			}
+			vm.Params = append(vm.Params, rows...)
+			paramNames = append(paramNames, string(*p.ContentDescriptorObject.Name))
+		}
+	}
+	if m.Result != nil && m.Result.ContentDescriptorObject != nil {
+		rows, err := contentDescriptorRows(*m.Result.ContentDescriptorObject)
+		if err != nil {
+			return vm, fmt.Errorf("method %s: %w", vm.Name, err)
+		}
+		vm.Result = rows
+	}
+	if m.Errors != nil {
+		for _, e := range *m.Errors {
+			if e.ErrorObject == nil || e.ErrorObject.Code == nil {
+				continue
+			}
+			row := errorRow{Code: int64(*e.ErrorObject.Code)}
+			if e.ErrorObject.Message != nil {
+				row.Message = string(*e.ErrorObject.Message)
+			}
+			vm.Errors = append(vm.Errors, row)
+		}
+	}
+
+	byName := m.ParamStructure != nil && *m.ParamStructure == meta_schema.MethodObjectParamStructureEnum1
+	if m.Examples != nil {
+		for i, e := range *m.Examples {
+			if e.ExamplePairingObject == nil {
+				continue
+			}
+			ex, err := newExample(vm.Name, i+1, *e.ExamplePairingObject, paramNames, byName, serverURL)
+			if err != nil {
+				return vm, fmt.Errorf("method %s: %w", vm.Name, err)
+			}
+			vm.Examples = append(vm.Examples, ex)
+		}
+	}
+	return vm, nil
+}
+
+func newExample(methodName string, id int, pairing meta_schema.ExamplePairingObject, paramNames []string, byName bool, serverURL string) (example, error) {
+	ex := example{Name: fmt.Sprintf("Example %d", id)}
+	if pairing.Name != nil && *pairing.Name != "" {
+		ex.Name = string(*pairing.Name)
+	}
+
+	values := []interface{}{}
+	if pairing.Params != nil {
+		for _, p := range *pairing.Params {
+			if p.ExampleObject != nil && p.ExampleObject.Value != nil {
+				values = append(values, *p.ExampleObject.Value)
+			} else {
+				values = append(values, nil)
+			}
+		}
+	}
+	var params interface{} = values
+	if byName {
+		named := map[string]interface{}{}
+		for i, v := range values {
+			if i < len(paramNames) {
+				named[paramNames[i]] = v
+			}
+		}
+		params = named
+	}
+	req := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"method":  methodName,
+		"params":  params,
+	}
+	var result interface{}
+	if pairing.Result != nil && pairing.Result.ExampleObject != nil && pairing.Result.ExampleObject.Value != nil {
+		result = *pairing.Result.ExampleObject.Value
+	}
+	res := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"result":  result,
+	}
+
+	b, err := json.MarshalIndent(req, "", "  ")
+	if err != nil {
+		return ex, err
+	}
+	ex.Request = string(b)
+	if b, err = json.MarshalIndent(res, "", "  "); err != nil {
+		return ex, err
+	}
+	ex.Response = string(b)
+
+	compact, err := json.Marshal(req)
+	if err != nil {
+		return ex, err
+	}
+	ex.Curl = fmt.Sprintf("curl -s -X POST -H 'Content-Type: application/json' --data '%s' %s",
+		strings.ReplaceAll(string(compact), "'", `'\''`), serverURL)
+	return ex, nil
+}
+
+func contentDescriptorRows(cd meta_schema.ContentDescriptorObject) ([]schemaRow, error) {
+	s, err := schemajson.FromJSONSchema(cd.Schema)
+	if err != nil {
+		return nil, err
+	}
+	name := ""
+	if cd.Name != nil {
+		name = string(*cd.Name)
+	}
+	desc := ""
+	if cd.Summary != nil {
+		desc = strings.TrimSpace(string(*cd.Summary))
+	}
+	if desc == "" && cd.Description != nil {
+		desc = string(*cd.Description)
+	}
+	required := cd.Required != nil && bool(*cd.Required)
+	deprecated := cd.Deprecated != nil && bool(*cd.Deprecated)
+	return schemaRows(name, 0, s, required, deprecated, desc), nil
+}
+
+// schemaRows flattens a schema and its nested properties into table rows.
+func schemaRows(path string, depth int, s schemajson.Schema, required, deprecated bool, desc string) []schemaRow {
+	if desc == "" {
+		desc = schemajson.String(s, "description")
+	}
+	rows := []schemaRow{{
+		Path:        path,
+		Depth:       depth,
+		Type:        typeString(s),
+		Required:    required,
+		Deprecated:  deprecated,
+		Description: desc,
+	}}
+	if depth > 8 {
+		// Guard against unexpectedly deep (or recursive) schemas.
+		return rows
+	}
+	req := map[string]bool{}
+	for _, r := range schemajson.Required(s) {
+		req[r] = true
+	}
+	props := schemajson.Properties(s)
+	for _, p := range schemajson.PropertyNames(s) {
+		rows = append(rows, schemaRows(path+"."+p, depth+1, props[p], req[p], false, "")...)
+	}
+	if items := schemajson.Items(s); items != nil && (len(schemajson.Properties(items)) > 0 || schemajson.Items(items) != nil) {
+		for _, r := range schemaRows(path+"[]", depth+1, items, false, false, "")[1:] {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// typeString renders a short human readable type for a schema, eg. "integer", "string (date-time)", "array<object>".
+func typeString(s schemajson.Schema) string {
+	if s == nil {
+		return "any"
+	}
+	types := schemajson.Types(s)
+	parts := []string{}
+	for _, t := range types {
+		switch t {
+		case "array":
+			parts = append(parts, "array<"+typeString(schemajson.Items(s))+">")
+		default:
+			parts = append(parts, t)
+		}
+	}
+	out := strings.Join(parts, " | ")
+	if out == "" {
+		for _, k := range []string{"oneOf", "anyOf", "allOf"} {
+			if _, ok := s[k]; ok {
+				out = k
+			}
+		}
+	}
+	if out == "" {
+		out = "any"
+	}
+	if f := schemajson.String(s, "format"); f != "" {
+		out += " (" + f + ")"
+	}
+	if e := schemajson.Enum(s); e != nil {
+		vals := []string{}
+		for _, v := range e {
+			b, _ := json.Marshal(v)
+			vals = append(vals, string(b))
+		}
+		out += " enum: " + strings.Join(vals, ", ")
+	}
+	return out
+}
+
+func anchor(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,151 @@
+package openrpcrender
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	go_openrpc_reflect "github.com/etclabscore/go-openrpc-reflect"
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakearithmetic"
+	meta_schema "github.com/open-rpc/meta-schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDocument(t *testing.T) *meta_schema.OpenrpcDocument {
+	d := &go_openrpc_reflect.Document{}
+	d.WithMeta(&go_openrpc_reflect.MetaT{
+		GetServersFn: func() func(listeners []net.Listener) (*meta_schema.Servers, error) {
+			return func([]net.Listener) (*meta_schema.Servers, error) {
+				url, name := "http://calculator.example.com", "calculator"
+				return &meta_schema.Servers{{
+					Url:  (*meta_schema.ServerObjectUrl)(&url),
+					Name: (*meta_schema.ServerObjectName)(&name),
+				}}, nil
+			}
+		},
+		GetInfoFn: func() (info *meta_schema.InfoObject) {
+			title, version := "Calculator API", "1.0.0"
+			return &meta_schema.InfoObject{
+				Title:   (*meta_schema.InfoObjectProperties)(&title),
+				Version: (*meta_schema.InfoObjectVersion)(&version),
+			}
+		},
+		GetExternalDocsFn: func() (exdocs *meta_schema.ExternalDocumentationObject) {
+			return nil
+		},
+	})
+	d.WithReflector(go_openrpc_reflect.EthereumReflector)
+	d.RegisterReceiver(&fakearithmetic.Calculator{})
+	doc, err := d.Discover()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Decorate calculator_add with a tag and an example pairing.
+	for i, m := range *doc.Methods {
+		if *m.Name != "calculator_add" {
+			continue
+		}
+		tag := "arithmetic"
+		(*doc.Methods)[i].Tags = &meta_schema.MethodObjectTags{
+			{TagObject: &meta_schema.TagObject{Name: (*meta_schema.TagObjectName)(&tag)}},
+		}
+		name := "one plus two"
+		var a, b, sum meta_schema.ExampleObjectValue = 1, 2, 3
+		(*doc.Methods)[i].Examples = &meta_schema.MethodObjectExamples{
+			{ExamplePairingObject: &meta_schema.ExamplePairingObject{
+				Name: (*meta_schema.ExamplePairingObjectName)(&name),
+				Params: &meta_schema.ExamplePairingObjectParams{
+					{ExampleObject: &meta_schema.ExampleObject{Value: &a}},
+					{ExampleObject: &meta_schema.ExampleObject{Value: &b}},
+				},
+				Result: &meta_schema.ExamplePairingObjectResult{
+					ExampleObject: &meta_schema.ExampleObject{Value: &sum},
+				},
+			}},
+		}
+	}
+	return doc
+}
+
+func TestMarkdown(t *testing.T) {
+	doc := newTestDocument(t)
+	buf := &bytes.Buffer{}
+	if !assert.NoError(t, Markdown(buf, doc, Options{})) {
+		t.FailNow()
+	}
+	out := buf.String()
+	t.Log(out)
+
+	for _, want := range []string{
+		"# Calculator API",
+		"- [calculator_add](#calculator_add)",
+		"- [calculator_div](#calculator_div) _(deprecated)_",
+		"> **Deprecated**",
+		"| `argA` | integer | yes | int |",
+		// Nested properties of the calculator_last result.
+		"| &nbsp;&nbsp;`calculation.Method` | string | no |  |",
+		"#### one plus two",
+		`"method": "calculator_add"`,
+		`"result": 3`,
+		`curl -s -X POST -H 'Content-Type: application/json' --data '{"id":1,"jsonrpc":"2.0","method":"calculator_add","params":[1,2]}' http://calculator.example.com`,
+	} {
+		assert.Contains(t, out, want)
+	}
+}
+
+func TestMarkdownByTag(t *testing.T) {
+	files, err := MarkdownByTag(newTestDocument(t), Options{})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Len(t, files, 2)
+	assert.Contains(t, string(files["arithmetic.md"]), "### calculator_add")
+	assert.NotContains(t, string(files["arithmetic.md"]), "### calculator_div")
+	assert.Contains(t, string(files[UntaggedFile]), "### calculator_div")
+}
+
+func TestHTML(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if !assert.NoError(t, HTML(buf, newTestDocument(t), Options{ServerURL: "http://localhost:1234"})) {
+		t.FailNow()
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		`<title>Calculator API</title>`,
+		`<input id="search"`,
+		`<li data-search="calculator_add add adds two integers together. arithmetic"><a href="#calculator_add">calculator_add</a></li>`,
+		`<li data-search="calculator_div`,
+		`class="deprecated"`,
+		`<div class="banner"><strong>Deprecated:</strong>`,
+		`<pre><code>func (c *Calculator) Add(argA, argB int) int {`,
+		`http://localhost:1234`,
+		`<span class="tag">arithmetic</span>`,
+	} {
+		assert.Contains(t, out, want)
+	}
+	// Self-contained: no external assets.
+	assert.NotContains(t, out, `<link `)
+	assert.NotContains(t, out, `src="http`)
+	assert.Contains(t, out, `document.getElementById("search")`)
+}
+
+func TestWriteSite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "openrpcrender")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, WriteSite(dir, newTestDocument(t), Options{}))
+	for _, f := range []string{"index.html", "openrpc.json"} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, f))
+		assert.NoError(t, err)
+		assert.True(t, strings.Contains(string(b), "calculator_add"), f)
+	}
+}
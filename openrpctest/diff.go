@@ -0,0 +1,109 @@
+package openrpctest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// Diff returns a line diff from want to got, in the style of a unified diff:
+// removed lines are prefixed with "-", added lines with "+", and
+// changes are shown with a few lines of surrounding context.
+// It returns "" if the texts are equal.
+func Diff(want, got string) string {
+	if want == got {
+		return ""
+	}
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	ops := diffLines(a, b)
+
+	// Mark the ops to print: every change, and the context around it.
+	show := make([]bool, len(ops))
+	for i, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+		for j := i - diffContext; j <= i+diffContext; j++ {
+			if j >= 0 && j < len(ops) {
+				show[j] = true
+			}
+		}
+	}
+
+	out := &strings.Builder{}
+	for i, op := range ops {
+		if !show[i] {
+			continue
+		}
+		if i == 0 || !show[i-1] {
+			fmt.Fprintf(out, "@@ -%d +%d @@\n", op.aLine+1, op.bLine+1)
+		}
+		fmt.Fprintf(out, "%c %s\n", op.kind, op.text)
+	}
+	return out.String()
+}
+
+type diffOp struct {
+	kind         byte // ' ', '-' or '+'
+	text         string
+	aLine, bLine int
+}
+
+// diffLines computes a minimal line edit script from a to b,
+// using the longest common subsequence of their lines.
+func diffLines(a, b []string) []diffOp {
+	// Trim the common prefix and suffix, which is most of a document in practice.
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	ma, mb := a[pre:len(a)-suf], b[pre:len(b)-suf]
+
+	// lcs[i][j] is the length of the LCS of ma[i:] and mb[j:].
+	lcs := make([][]int, len(ma)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(mb)+1)
+	}
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			if ma[i] == mb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for i := 0; i < pre; i++ {
+		ops = append(ops, diffOp{' ', a[i], i, i})
+	}
+	i, j := 0, 0
+	for i < len(ma) || j < len(mb) {
+		switch {
+		case i < len(ma) && j < len(mb) && ma[i] == mb[j]:
+			ops = append(ops, diffOp{' ', ma[i], pre + i, pre + j})
+			i++
+			j++
+		case j < len(mb) && (i == len(ma) || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, diffOp{'+', mb[j], pre + i, pre + j})
+			j++
+		default:
+			ops = append(ops, diffOp{'-', ma[i], pre + i, pre + j})
+			i++
+		}
+	}
+	for k := 0; k < suf; k++ {
+		ops = append(ops, diffOp{' ', a[len(a)-suf+k], len(a) - suf + k, len(b) - suf + k})
+	}
+	return ops
+}
@@ -0,0 +1,156 @@
+package openrpctest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Golden compares the document with the golden file at path.
+// Both are canonicalized before comparison, and differences are reported as a line diff.
+// With update, the golden file is (re)written instead, eg. as the test package's own -update flag is set:
+//
+//	var update = flag.Bool("update", false, "update golden files")
+//	...
+//	openrpctest.Golden(t, "testdata/openrpc.json", doc, *update)
+func Golden(t TestingT, path string, doc interface{}, update bool) {
+	t.Helper()
+
+	got, err := Canonicalize(doc)
+	if err != nil {
+		t.Fatalf("canonicalize document: %v", err)
+		return
+	}
+
+	if update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("update golden file: %v", err)
+			return
+		}
+		if err := ioutil.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("update golden file: %v", err)
+			return
+		}
+		t.Logf("updated golden file %s", path)
+		return
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("golden file %s does not exist; run Golden with update to create it", path)
+		return
+	}
+	if err != nil {
+		t.Fatalf("read golden file: %v", err)
+		return
+	}
+	var golden interface{}
+	if err := json.Unmarshal(b, &golden); err != nil {
+		t.Fatalf("golden file %s: %v", path, err)
+		return
+	}
+	want, err := Canonicalize(golden)
+	if err != nil {
+		t.Fatalf("canonicalize golden file: %v", err)
+		return
+	}
+
+	if !bytes.Equal(want, got) {
+		t.Errorf("document does not match golden file %s (run Golden with update to accept the changes):\n%s",
+			path, Diff(string(want), string(got)))
+	}
+}
+
+// Canonicalize returns the indented JSON encoding of the document (or any JSON value)
+// with ordering noise removed, so that semantically equal documents encode equally.
+//
+// Object keys are sorted, as are methods (by name), tags (by name), and the values of
+// the "required" and "type" schema keywords. Arrays whose order is significant,
+// eg. method params, are left as they are.
+func Canonicalize(doc interface{}) ([]byte, error) {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	canonicalize(v)
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func canonicalize(v interface{}) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, val := range vv {
+			canonicalize(val)
+			arr, ok := val.([]interface{})
+			if !ok {
+				continue
+			}
+			switch k {
+			case "methods", "tags":
+				sortByName(arr)
+			case "required", "type":
+				sortStrings(arr)
+			}
+		}
+	case []interface{}:
+		for _, val := range vv {
+			canonicalize(val)
+		}
+	}
+}
+
+// sortByName sorts objects by their "name" value.
+// Arrays containing other values are left as they are.
+func sortByName(arr []interface{}) {
+	names := make([]string, len(arr))
+	for i, v := range arr {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return
+		}
+		if names[i], ok = m["name"].(string); !ok {
+			return
+		}
+	}
+	sort.Stable(byName{arr, names})
+}
+
+type byName struct {
+	values []interface{}
+	names  []string
+}
+
+func (b byName) Len() int           { return len(b.values) }
+func (b byName) Less(i, j int) bool { return b.names[i] < b.names[j] }
+func (b byName) Swap(i, j int) {
+	b.values[i], b.values[j] = b.values[j], b.values[i]
+	b.names[i], b.names[j] = b.names[j], b.names[i]
+}
+
+// sortStrings sorts arrays of strings.
+// Arrays containing other values are left as they are.
+func sortStrings(arr []interface{}) {
+	for _, v := range arr {
+		if _, ok := v.(string); !ok {
+			return
+		}
+	}
+	sort.Slice(arr, func(i, j int) bool {
+		return arr[i].(string) < arr[j].(string)
+	})
+}
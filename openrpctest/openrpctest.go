@@ -0,0 +1,175 @@
+// Package openrpctest provides test helpers for OpenRPC documents:
+// golden-file comparison of discovered documents, and assertions about their contents.
+//
+//	func TestDocument(t *testing.T) {
+//		doc := openrpctest.Discover(t, myDocument())
+//		openrpctest.Golden(t, "testdata/openrpc.json", doc, *update)
+//		openrpctest.MethodsHaveSummary(t, doc)
+//		openrpctest.HasParam(t, doc, "calculator_add", "argA", "integer")
+//	}
+//
+// where update is the test package's own -update flag, eg.
+//
+//	var update = flag.Bool("update", false, "update golden files")
+//
+// Run the tests with -update to write the golden files.
+package openrpctest
+
+import (
+	"fmt"
+	"strings"
+
+	go_openrpc_reflect "github.com/etclabscore/go-openrpc-reflect"
+	"github.com/etclabscore/go-openrpc-reflect/internal/schemajson"
+	"github.com/etclabscore/go-openrpc-reflect/openrpcdiff"
+	"github.com/etclabscore/go-openrpc-reflect/openrpcgen"
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// TestingT is the subset of testing.TB used by the helpers.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+	Logf(format string, args ...interface{})
+}
+
+// Discover discovers the document, failing the test on error.
+func Discover(t TestingT, d *go_openrpc_reflect.Document) *meta_schema.OpenrpcDocument {
+	t.Helper()
+	doc, err := d.Discover()
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	return doc
+}
+
+// MethodsHaveSummary asserts that every method of the document has a non-empty summary.
+func MethodsHaveSummary(t TestingT, doc *meta_schema.OpenrpcDocument) bool {
+	t.Helper()
+	ok := true
+	for _, m := range methods(doc) {
+		if m.Summary == nil || strings.TrimSpace(string(*m.Summary)) == "" {
+			t.Errorf("method %s has no summary", methodName(m))
+			ok = false
+		}
+	}
+	return ok
+}
+
+// HasMethod asserts that the document has the named method, and returns it.
+func HasMethod(t TestingT, doc *meta_schema.OpenrpcDocument, method string) (meta_schema.MethodObject, bool) {
+	t.Helper()
+	for _, m := range methods(doc) {
+		if methodName(m) == method {
+			return m, true
+		}
+	}
+	t.Errorf("method %s not found; have %s", method, strings.Join(methodNames(doc), ", "))
+	return meta_schema.MethodObject{}, false
+}
+
+// HasParam asserts that the method has a param with the given name,
+// whose schema declares the given type (eg. "integer", "object").
+// An empty type matches any schema.
+func HasParam(t TestingT, doc *meta_schema.OpenrpcDocument, method, param, typ string) bool {
+	t.Helper()
+	m, ok := HasMethod(t, doc, method)
+	if !ok {
+		return false
+	}
+	names := []string{}
+	if m.Params != nil {
+		for _, p := range *m.Params {
+			cd := p.ContentDescriptorObject
+			if cd == nil || cd.Name == nil {
+				continue
+			}
+			if string(*cd.Name) == param {
+				return hasType(t, fmt.Sprintf("method %s param %s", method, param), cd.Schema, typ)
+			}
+			names = append(names, string(*cd.Name))
+		}
+	}
+	t.Errorf("method %s has no param %s; have [%s]", method, param, strings.Join(names, ", "))
+	return false
+}
+
+// HasResult asserts that the method's result schema declares the given type.
+// An empty type matches any schema.
+func HasResult(t TestingT, doc *meta_schema.OpenrpcDocument, method, typ string) bool {
+	t.Helper()
+	m, ok := HasMethod(t, doc, method)
+	if !ok {
+		return false
+	}
+	if m.Result == nil || m.Result.ContentDescriptorObject == nil {
+		t.Errorf("method %s has no result", method)
+		return false
+	}
+	return hasType(t, fmt.Sprintf("method %s result", method), m.Result.ContentDescriptorObject.Schema, typ)
+}
+
+func hasType(t TestingT, what string, schema *meta_schema.JSONSchema, typ string) bool {
+	t.Helper()
+	if typ == "" {
+		return true
+	}
+	s, err := schemajson.FromJSONSchema(schema)
+	if err != nil {
+		t.Errorf("%s: %v", what, err)
+		return false
+	}
+	if !schemajson.HasType(s, typ) {
+		t.Errorf("%s: want type %s, got %v", what, typ, schemajson.Types(s))
+		return false
+	}
+	return true
+}
+
+// NoDrift asserts that a document discovered from an implementation matches
+// the source document it was generated from. See openrpcgen.Check.
+func NoDrift(t TestingT, source, discovered *meta_schema.OpenrpcDocument) bool {
+	t.Helper()
+	drifts := openrpcgen.Check(source, discovered)
+	for _, d := range drifts {
+		t.Errorf("drift: %s", d)
+	}
+	return len(drifts) == 0
+}
+
+// NoBreakingChanges asserts that no change from the old document to the new one
+// would break existing clients. See openrpcdiff.Compare.
+func NoBreakingChanges(t TestingT, old, new *meta_schema.OpenrpcDocument) bool {
+	t.Helper()
+	ok := true
+	for _, c := range openrpcdiff.Compare(old, new) {
+		if c.Breaking {
+			t.Errorf("breaking change: %s", c)
+			ok = false
+		}
+	}
+	return ok
+}
+
+func methods(doc *meta_schema.OpenrpcDocument) []meta_schema.MethodObject {
+	if doc == nil || doc.Methods == nil {
+		return nil
+	}
+	return *doc.Methods
+}
+
+func methodName(m meta_schema.MethodObject) string {
+	if m.Name == nil {
+		return ""
+	}
+	return string(*m.Name)
+}
+
+func methodNames(doc *meta_schema.OpenrpcDocument) []string {
+	out := []string{}
+	for _, m := range methods(doc) {
+		out = append(out, methodName(m))
+	}
+	return out
+}
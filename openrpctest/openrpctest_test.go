@@ -0,0 +1,172 @@
+package openrpctest
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	go_openrpc_reflect "github.com/etclabscore/go-openrpc-reflect"
+	meta_schema "github.com/open-rpc/meta-schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Greeter is the receiver documented by the tests.
+type Greeter struct{}
+
+// Hello says hello to the named person.
+func (g *Greeter) Hello(name string, times int) (string, error) {
+	return strings.Repeat("hello "+name, times), nil
+}
+
+// Count returns the number of greetings made.
+func (g *Greeter) Count() (int, error) {
+	return 0, nil
+}
+
+func (g *Greeter) Wave() error {
+	return nil
+}
+
+// newDocument returns a document for Greeter with stable meta information,
+// so that it can be compared with a golden file.
+func newDocument() *go_openrpc_reflect.Document {
+	d := &go_openrpc_reflect.Document{}
+	d.WithMeta(&go_openrpc_reflect.MetaT{
+		GetServersFn: func() func(listeners []net.Listener) (*meta_schema.Servers, error) {
+			return func([]net.Listener) (*meta_schema.Servers, error) { return nil, nil }
+		},
+		GetInfoFn: func() (info *meta_schema.InfoObject) {
+			title, version := "Greeter API", "1.0.0"
+			return &meta_schema.InfoObject{
+				Title:   (*meta_schema.InfoObjectProperties)(&title),
+				Version: (*meta_schema.InfoObjectVersion)(&version),
+			}
+		},
+		GetExternalDocsFn: func() (exdocs *meta_schema.ExternalDocumentationObject) {
+			return nil
+		},
+	})
+	d.WithReflector(go_openrpc_reflect.EthereumReflector)
+	d.RegisterReceiverName("greeter", &Greeter{})
+	return d
+}
+
+// recorder is a TestingT recording failures instead of failing the test.
+type recorder struct {
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Helper() {}
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+	r.fatal = true
+}
+func (r *recorder) Logf(format string, args ...interface{}) {}
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGolden(t *testing.T) {
+	doc := Discover(t, newDocument())
+	Golden(t, "testdata/greeter.openrpc.json", doc, *update)
+
+	// Reordering methods is noise.
+	methods := *doc.Methods
+	methods[0], methods[1] = methods[1], methods[0]
+	Golden(t, "testdata/greeter.openrpc.json", doc, *update)
+
+	// The failure cases must not write golden files.
+	// Renaming a param is not.
+	cd := (*methods[0].Params)[0].ContentDescriptorObject
+	if *cd.Name != "name" {
+		cd = (*methods[1].Params)[0].ContentDescriptorObject
+	}
+	*cd.Name = "who"
+	r := &recorder{}
+	Golden(r, "testdata/greeter.openrpc.json", doc, false)
+	if assert.Len(t, r.errors, 1) {
+		assert.Contains(t, r.errors[0], "does not match golden file testdata/greeter.openrpc.json")
+		assert.Contains(t, r.errors[0], `-           "name": "name",`)
+		assert.Contains(t, r.errors[0], `+           "name": "who",`)
+	}
+
+	r = &recorder{}
+	Golden(r, "testdata/missing.openrpc.json", doc, false)
+	assert.True(t, r.fatal)
+	assert.Contains(t, r.errors[0], "run Golden with update")
+}
+
+func TestCanonicalize(t *testing.T) {
+	a, err := Canonicalize(map[string]interface{}{
+		"methods": []interface{}{
+			map[string]interface{}{"name": "b", "params": []interface{}{"y", "x"}},
+			map[string]interface{}{"name": "a", "required": []interface{}{"z", "w"}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  "methods": [
+    {
+      "name": "a",
+      "required": [
+        "w",
+        "z"
+      ]
+    },
+    {
+      "name": "b",
+      "params": [
+        "y",
+        "x"
+      ]
+    }
+  ]
+}
+`, string(a))
+}
+
+func TestDiff(t *testing.T) {
+	assert.Equal(t, "", Diff("a\nb\n", "a\nb\n"))
+	assert.Equal(t, "@@ -1 +1 @@\n  a\n- b\n+ c\n  d\n", Diff("a\nb\nd\n", "a\nc\nd\n"))
+
+	// Changes far apart get separate hunks.
+	want := strings.Repeat("x\n", 10) + "a\n" + strings.Repeat("x\n", 10) + "b\n"
+	got := strings.Repeat("x\n", 10) + "A\n" + strings.Repeat("x\n", 10) + "B\n"
+	assert.Equal(t, 2, strings.Count(Diff(want, got), "@@ -"))
+}
+
+func TestAssertions(t *testing.T) {
+	doc := Discover(t, newDocument())
+
+	assert.True(t, HasParam(t, doc, "greeter_hello", "name", "string"))
+	assert.True(t, HasParam(t, doc, "greeter_hello", "times", ""))
+	assert.True(t, HasResult(t, doc, "greeter_count", "integer"))
+
+	r := &recorder{}
+	assert.False(t, HasParam(r, doc, "greeter_hello", "times", "string"))
+	assert.False(t, HasParam(r, doc, "greeter_hello", "nobody", ""))
+	assert.False(t, HasParam(r, doc, "greeter_bye", "name", ""))
+	assert.Equal(t, []string{
+		"method greeter_hello param times: want type string, got [integer]",
+		"method greeter_hello has no param nobody; have [name, times]",
+		"method greeter_bye not found; have greeter_count, greeter_hello, greeter_wave",
+	}, r.errors)
+
+	r = &recorder{}
+	assert.False(t, MethodsHaveSummary(r, doc))
+	assert.Equal(t, []string{"method greeter_wave has no summary"}, r.errors)
+
+	assert.True(t, NoDrift(t, doc, doc))
+	assert.True(t, NoBreakingChanges(t, doc, doc))
+
+	changed := Discover(t, newDocument())
+	*changed.Methods = (*changed.Methods)[:1]
+	r = &recorder{}
+	assert.False(t, NoBreakingChanges(r, doc, changed))
+	assert.Len(t, r.errors, 2)
+}
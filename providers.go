@@ -0,0 +1,127 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/alecthomas/jsonschema"
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// SchemaProvider is implemented by types which document their own schemas.
+// Its schema is used wherever the type is, whether as a param or result, or as a field or item of one,
+// in place of the reflected schema and of its SchemaTypeMap mapping.
+// The method is called on the type's zero value, or a pointer to it for pointer receivers.
+type SchemaProvider interface {
+	OpenRPCSchema() meta_schema.JSONSchema
+}
+
+// SchemaExamplesProvider is implemented by types which provide examples of themselves,
+// which the StandardReflector's SchemaExamples returns by default.
+// The method is called as SchemaProvider's is.
+type SchemaExamplesProvider interface {
+	OpenRPCSchemaExamples() (*meta_schema.Examples, error)
+}
+
+var (
+	schemaProviderType         = reflect.TypeOf((*SchemaProvider)(nil)).Elem()
+	schemaExamplesProviderType = reflect.TypeOf((*SchemaExamplesProvider)(nil)).Elem()
+)
+
+// implementer returns a value of the type implementing the interface: its zero value, a pointer to it if
+// its pointer implements the interface, or a pointer to a zero value for pointer types.
+func implementer(ty reflect.Type, iface reflect.Type) (interface{}, bool) {
+	switch {
+	case ty.Kind() == reflect.Interface:
+		return nil, false
+	case ty.Kind() == reflect.Ptr && ty.Implements(iface):
+		return reflect.New(ty.Elem()).Interface(), true
+	case ty.Implements(iface):
+		return reflect.Zero(ty).Interface(), true
+	case reflect.PtrTo(ty).Implements(iface):
+		return reflect.New(ty).Interface(), true
+	}
+	return nil, false
+}
+
+func schemaProviderOf(ty reflect.Type) (SchemaProvider, bool) {
+	v, ok := implementer(ty, schemaProviderType)
+	if !ok {
+		return nil, false
+	}
+	return v.(SchemaProvider), true
+}
+
+func schemaExamplesProviderOf(ty reflect.Type) (SchemaExamplesProvider, bool) {
+	v, ok := implementer(ty, schemaExamplesProviderType)
+	if !ok {
+		return nil, false
+	}
+	return v.(SchemaExamplesProvider), true
+}
+
+// providedSchemaKey marks the placeholders of provided schemas in reflected schemas.
+const providedSchemaKey = "x-go-openrpc-reflect-provided-schema"
+
+// providedSchemas collects the schemas of the SchemaProviders nested in a reflected schema.
+// They are reflected as placeholders, so that the schema mutations leave them as provided,
+// and put in place of those once the schema is mutated.
+type providedSchemas struct {
+	schemas []meta_schema.JSONSchema
+}
+
+// typeMap returns a SchemaTypeMap mapping SchemaProviders to placeholders of their schemas.
+func (p *providedSchemas) typeMap() SchemaTypeMap {
+	return func(ty reflect.Type) *jsonschema.Type {
+		provider, ok := schemaProviderOf(ty)
+		if !ok {
+			return nil
+		}
+		p.schemas = append(p.schemas, provider.OpenRPCSchema())
+		return &jsonschema.Type{Extras: map[string]interface{}{
+			providedSchemaKey: fmt.Sprint(len(p.schemas) - 1),
+		}}
+	}
+}
+
+// replace puts the provided schemas in place of their placeholders in a schema's JSON.
+func (p *providedSchemas) replace(b []byte) ([]byte, error) {
+	if len(p.schemas) == 0 {
+		return b, nil
+	}
+	provided := make(map[string]interface{}, len(p.schemas))
+	for i, s := range p.schemas {
+		sb, err := json.Marshal(s)
+		if err != nil {
+			return nil, fmt.Errorf("provided schema: %w", err)
+		}
+		var v interface{}
+		if err := json.Unmarshal(sb, &v); err != nil {
+			return nil, fmt.Errorf("provided schema: %w", err)
+		}
+		provided[fmt.Sprint(i)] = v
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(replaceProvidedSchemas(v, provided))
+}
+
+func replaceProvidedSchemas(v interface{}, provided map[string]interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if key, ok := v[providedSchemaKey].(string); ok {
+			return provided[key]
+		}
+		for k, vv := range v {
+			v[k] = replaceProvidedSchemas(vv, provided)
+		}
+	case []interface{}:
+		for i, vv := range v {
+			v[i] = replaceProvidedSchemas(vv, provided)
+		}
+	}
+	return v
+}
@@ -0,0 +1,71 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"testing"
+
+	meta_schema "github.com/open-rpc/meta-schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// ProviderMoney documents itself as a decimal string.
+type ProviderMoney struct {
+	Units int64
+	Nanos int32
+}
+
+func (ProviderMoney) OpenRPCSchema() meta_schema.JSONSchema {
+	schema, _ := unmarshalJSONSchema([]byte(`{"title": "Money", "type": "string", "pattern": "^-?[0-9]+(\\.[0-9]{1,9})?$"}`))
+	return schema
+}
+
+func (ProviderMoney) OpenRPCSchemaExamples() (*meta_schema.Examples, error) {
+	return &meta_schema.Examples{"1.50"}, nil
+}
+
+// ProviderCurrency documents itself with a pointer receiver.
+type ProviderCurrency struct {
+	code string
+}
+
+func (c *ProviderCurrency) OpenRPCSchema() meta_schema.JSONSchema {
+	schema, _ := unmarshalJSONSchema([]byte(`{"type": "object", "properties": {"code": {"type": "string"}}}`))
+	return schema
+}
+
+type ProviderInvoice struct {
+	Total    ProviderMoney     `json:"total"`
+	Lines    []ProviderMoney   `json:"lines"`
+	Currency *ProviderCurrency `json:"currency"`
+}
+
+type providerService struct{}
+
+func (p *providerService) Total(invoice ProviderInvoice) (ProviderMoney, error) {
+	return invoice.Total, nil
+}
+
+func TestSchemaProvider(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(EthereumReflector)
+	d.RegisterReceiverName("invoice", &providerService{})
+
+	doc, err := d.Discover()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	assert.NoError(t, err)
+
+	t.Log(string(b))
+
+	testJSON(t, b, map[string]interface{}{
+		`methods.0.params.0.schema.type.0`:                       "object",
+		`methods.0.params.0.schema.properties.total.title`:       "Money",
+		`methods.0.params.0.schema.properties.total.type`:        "string",
+		`methods.0.params.0.schema.properties.lines.items.title`: "Money",
+		`methods.0.params.0.schema.properties.currency.type`:     "object",
+		`methods.0.params.0.schema.properties.currency.required`: nil,
+		`methods.0.result.schema.title`:                          "Money",
+		`methods.0.result.schema.examples.0`:                     "1.50",
+	})
+}
@@ -0,0 +1,173 @@
+package go_openrpc_reflect
+
+import (
+	"net"
+	"os"
+	"reflect"
+
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// ServerListener is a listener together with details of how its server is documented.
+// Register it in place of the listener it wraps; it can be served from as well.
+type ServerListener struct {
+	net.Listener
+
+	// Scheme is the scheme of the server's URL, eg. "ws".
+	// If empty, it is "unix" for unix sockets, "https" for TLS listeners and otherwise "http".
+	Scheme string
+	// Host is the public hostname of the server, used in place of the address the listener is bound to.
+	// If empty, listeners bound to unspecified addresses (eg. 0.0.0.0) are documented with the machine's hostname.
+	Host string
+	// Path is appended to the server's URL, eg. "/rpc".
+	Path string
+
+	Name        string
+	Summary     string
+	Description string
+	// Variables are substituted into the URL where it has {braced} names, eg. in Host: "{region}.example.com".
+	Variables map[string]meta_schema.ServerObjectVariable
+}
+
+// listenerServer builds the server object documenting a listener.
+func listenerServer(listener net.Listener) meta_schema.ServerObject {
+	sl, ok := listener.(*ServerListener)
+	if !ok {
+		sl = &ServerListener{Listener: listener}
+	}
+	addr := sl.Addr()
+
+	scheme := sl.Scheme
+	if scheme == "" {
+		scheme = listenerScheme(sl.Listener)
+	}
+
+	var url string
+	if scheme == "unix" || addr.Network() == "unix" {
+		url = scheme + "://" + addr.String()
+	} else {
+		host, port, err := net.SplitHostPort(addr.String())
+		if err != nil {
+			host, port = addr.String(), ""
+		}
+		if sl.Host != "" {
+			host = sl.Host
+		} else if ip := net.ParseIP(host); ip != nil && ip.IsUnspecified() {
+			host = publicHostname()
+		}
+		if port != "" {
+			host = net.JoinHostPort(host, port)
+		}
+		url = scheme + "://" + host
+	}
+	url += sl.Path
+
+	name := sl.Name
+	if name == "" {
+		name = scheme
+	}
+	server := meta_schema.ServerObject{
+		Url:  (*meta_schema.ServerObjectUrl)(&url),
+		Name: (*meta_schema.ServerObjectName)(&name),
+	}
+	if sl.Summary != "" {
+		summary := sl.Summary
+		server.Summary = (*meta_schema.ServerObjectSummary)(&summary)
+	}
+	if sl.Description != "" {
+		description := sl.Description
+		server.Description = (*meta_schema.ServerObjectDescription)(&description)
+	}
+	if len(sl.Variables) > 0 {
+		variables := meta_schema.ServerObjectVariables{}
+		for k, v := range sl.Variables {
+			variables[k] = v
+		}
+		server.Variables = &variables
+	}
+	return server
+}
+
+// listenerScheme infers the URL scheme of a listener's server.
+func listenerScheme(listener net.Listener) string {
+	switch listener.Addr().Network() {
+	case "unix", "unixpacket":
+		return "unix"
+	}
+	// crypto/tls doesn't export its listener type.
+	ty := reflect.TypeOf(listener)
+	if ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+	if ty.PkgPath() == "crypto/tls" {
+		return "https"
+	}
+	return "http"
+}
+
+// publicHostname returns the machine's hostname, or localhost if it is unknown.
+func publicHostname() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return "localhost"
+	}
+	return host
+}
+
+// RegisterServer registers a server which isn't one of the document's listeners,
+// eg. a reverse proxy or load balancer in front of them.
+func (d *Document) RegisterServer(server meta_schema.ServerObject) {
+	d.RegisterServerTransport(server, "")
+}
+
+// RegisterServerTransport registers a server serving the transport; see RegisterServer.
+func (d *Document) RegisterServerTransport(server meta_schema.ServerObject, transport string) {
+	d.servers = append(d.servers, server)
+	d.serverTransports = append(d.serverTransports, transport)
+}
+
+// transportServers returns the registered servers serving the transport.
+func (d *Document) transportServers(transport string) []meta_schema.ServerObject {
+	out := []meta_schema.ServerObject{}
+	for i, s := range d.servers {
+		var transports []string
+		if t := d.serverTransports[i]; t != "" {
+			transports = []string{t}
+		}
+		if onTransport(transports, transport) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// RegisterReceiverListeners registers a named receiver whose methods are served by the listeners only,
+// eg. an archive node's endpoint. The listeners must be registered with the document as well.
+// The receiver's methods are documented with the listeners' servers, unless the reflector documents their servers itself,
+// and are left out of the documents of transports none of the listeners serve.
+func (d *Document) RegisterReceiverListeners(name string, receiver interface{}, listeners ...net.Listener) {
+	d.registerReceiver(name, receiver, nil, listeners)
+}
+
+// receiverServers returns the servers of the receiver's methods for the transport, from the listeners it's bound to.
+// It returns nil servers if the receiver isn't bound to listeners, and false if none of them serve the transport.
+func (d *Document) receiverServers(i int, transport string) (*meta_schema.Servers, bool, error) {
+	bound := d.receiverListeners[i]
+	if len(bound) == 0 {
+		return nil, true, nil
+	}
+	listeners := []net.Listener{}
+	for _, l := range d.transportListeners(transport) {
+		for _, b := range bound {
+			if l == b {
+				listeners = append(listeners, l)
+				break
+			}
+		}
+	}
+	if len(listeners) == 0 {
+		return nil, false, nil
+	}
+	servers, err := d.meta.GetServers()(listeners)
+	return servers, true, err
+}
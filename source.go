@@ -0,0 +1,90 @@
+package go_openrpc_reflect
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// astPackageFiles parses the source files of a named type's package, which is found
+// next to the method's own source file, or by go/build.
+// It returns nil if the source can't be found.
+func astPackageFiles(m reflect.Method, ty reflect.Type) (out []*ast.File) {
+	if ty.PkgPath() == "" {
+		return nil
+	}
+
+	dir, testDir := "", ""
+	if m.Func.IsValid() {
+		runtimeFunc := runtime.FuncForPC(m.Func.Pointer())
+		runtimeFile, _ := runtimeFunc.FileLine(runtimeFunc.Entry())
+		if !filepath.IsAbs(runtimeFile) {
+			return nil
+		}
+		dir = filepath.Dir(runtimeFile)
+		if methodInTestFile(m) {
+			testDir = dir
+		}
+		if runtimeFuncPkgPath(runtimeFunc) == ty.PkgPath() {
+			return parsePackageDir(dir, ty.PkgPath(), dir == testDir)
+		}
+	}
+	pkg, err := build.Import(strings.TrimSuffix(ty.PkgPath(), "_test"), dir, build.FindOnly)
+	if err != nil {
+		return nil
+	}
+	return parsePackageDir(pkg.Dir, ty.PkgPath(), pkg.Dir == testDir)
+}
+
+// parsePackageDir parses the source files of the package, or of its external test package, in a directory,
+// as go/build selects them by their build constraints. The package's own _test.go files are parsed with tests.
+func parsePackageDir(dir string, pkgPath string, tests bool) (out []*ast.File) {
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil
+	}
+	files := append(append([]string{}, pkg.GoFiles...), pkg.CgoFiles...)
+	if tests {
+		files = append(files, pkg.TestGoFiles...)
+	}
+	// External test packages share their directory with the package they test.
+	if strings.HasSuffix(pkgPath, "_test") {
+		files = pkg.XTestGoFiles
+	}
+	for _, file := range files {
+		astFile, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, file), nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		out = append(out, astFile)
+	}
+	return out
+}
+
+// runtimeFuncPkgPath returns the import path of the package declaring a function,
+// eg. github.com/a/b for github.com/a/b.(*T).Method.
+func runtimeFuncPkgPath(rf *runtime.Func) string {
+	name := rf.Name()
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return name
+	}
+	return name[:slash+1+dot]
+}
+
+// methodInTestFile tells whether a method is declared in a _test.go file, and so is of a test binary,
+// which is built with the test files of the method's package.
+func methodInTestFile(m reflect.Method) bool {
+	if !m.Func.IsValid() {
+		return false
+	}
+	runtimeFunc := runtime.FuncForPC(m.Func.Pointer())
+	runtimeFile, _ := runtimeFunc.FileLine(runtimeFunc.Entry())
+	return strings.HasSuffix(runtimeFile, "_test.go")
+}
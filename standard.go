@@ -45,14 +45,46 @@ type ReceiverReflectorT struct {
 	FnSchemaTypeMap func () func(ty reflect.Type) *jsonschema.Type
 	FnSchemaMutations func (ty reflect.Type) []func (*spec.Schema) func(*spec.Schema) error
 	FnSchemaExamples func (ty reflect.Type) (examples *meta_schema.Examples, err error)
+	FnGetMethodExtensions func(r reflect.Value, m reflect.Method, funcDecl *ast.FuncDecl) (Extensions, error)
+	FnGetContentDescriptorExtensions func(r reflect.Value, m reflect.Method, field *ast.Field) (Extensions, error)
+	FnGetSchemaExtensions func(r reflect.Value, m reflect.Method, field *ast.Field, ty reflect.Type) (Extensions, error)
+	FnSchemaValidationTags func() ValidationTags
 }
 
 type StandardReflectorT struct{
 	ReceiverReflectorT
+
+	// FlattenArgs documents struct args as by-name params, one per JSON field of the arg,
+	// matching the params objects JSON-RPC 2.0 clients send, eg. {"a": 1, "b": 2}.
+	// Params are named by their json tags, and are not required if tagged omitempty.
+	// Args which aren't structs are documented as a single by-position param, as by default.
+	FlattenArgs bool
+
+	// EncodingJSON documents schemas as encoding/json encodes values, rather than requiring all properties:
+	// fields which are omitempty or pointers are optional, pointers, slices and maps are nullable,
+	// fields tagged ",string" are strings, and embedded structs' fields are promoted as encoding/json promotes them.
+	// SchemaMutationRequireDefaultOn isn't a default schema mutation of this mode.
+	EncodingJSON bool
+
+	// Enums documents named string and integer types declared with two or more typed constants, eg.
+	// const ( StatusOpen OrderStatus = "open" ), as enums of the constants' values, described by their doc comments.
+	// Types of bit flags, whose constants are built by shifts, eg. 1 << iota, aren't enums.
+	Enums bool
+
+	// Strict fails reflection with a *SchemaError for schemas which couldn't be built faithfully,
+	// eg. of types unsupported by JSON Schema, rather than documenting them by fallback schemas.
+	// Otherwise, their warnings are collected, see Document.SchemaWarnings.
+	Strict bool
+
+	// warnings collects the warnings of the schemas reflected by a document's discovery,
+	// see Document.SchemaWarnings.
+	warnings *schemaWarnings
 }
 
 var StandardReflector = &StandardReflectorT{}
 
+// GetServers documents each listener as a server whose URL's scheme is inferred from the listener,
+// eg. http://127.0.0.1:8545. Register a *ServerListener to set the scheme, public host, path and other details.
 func (c *StandardReflectorT) GetServers() func (listeners []net.Listener) (*meta_schema.Servers, error) {
 	return func (listeners []net.Listener) (*meta_schema.Servers, error) {
 		if listeners == nil {
@@ -66,22 +98,22 @@ func (c *StandardReflectorT) GetServers() func (listeners []net.Listener) (*meta
 			if listener == nil {
 				continue
 			}
-			addr := listener.Addr().String()
-			network := listener.Addr().Network()
-			servers = append(servers, meta_schema.ServerObject{
-				Url:  (*meta_schema.ServerObjectUrl)(&addr),
-				Name: (*meta_schema.ServerObjectName)(&network),
-			})
+			servers = append(servers, listenerServer(listener))
 		}
 		return (*meta_schema.Servers)(&servers), nil
 	}
 }
 
 func (c *StandardReflectorT) ReceiverMethods(name string, receiver interface{}) ([]meta_schema.MethodObject, error) {
+	return c.receiverMethodsExtensions(name, receiver, nil)
+}
+
+// receiverMethodsExtensions is ReceiverMethods, collecting the methods' extensions in exts if it isn't nil.
+func (c *StandardReflectorT) receiverMethodsExtensions(name string, receiver interface{}, exts methodExtensions) ([]meta_schema.MethodObject, error) {
 	if c.FnReceiverMethods != nil {
 		return c.FnReceiverMethods(name, receiver)
 	}
-	return receiverMethods(c, name, receiver)
+	return receiverMethods(c, name, receiver, exts)
 }
 
 // ------------------------------------------------------------------------------
@@ -145,6 +177,12 @@ func (c *StandardReflectorT) GetMethodParams(r reflect.Value, m reflect.Method,
 	if c.FnGetMethodParams != nil {
 		return c.FnGetMethodParams(r, m, funcDecl)
 	}
+	return buildContentDescriptorObjects(c, r, m, c.methodParamFields(m, funcDecl))
+}
+
+// methodParamFields returns the fields and types of the params of a net/rpc method:
+// its args, or their fields with FlattenArgs set.
+func (c *StandardReflectorT) methodParamFields(m reflect.Method, funcDecl *ast.FuncDecl) []descriptorField {
 	// A case where expanded fields arg expression would fail (if anyof `funcDecl.Type.Params` == nil)
 	// should be caught by the IsMethodEligible condition.
 	if funcDecl.Type.Params == nil {
@@ -156,19 +194,23 @@ func (c *StandardReflectorT) GetMethodParams(r reflect.Value, m reflect.Method,
 	// We always want only the first param.
 	nf := expandedFields[0]
 	ty := m.Type.In(1)
-	cd, err := buildContentDescriptorObject(c, r, m, nf, ty)
-	if err != nil {
-		return nil, err
+	if c.FlattenArgs && isFlattenableArg(ty) {
+		return flattenedDescriptorFields(m, ty)
 	}
-
 	// Spec says params are always a list.
-	return []meta_schema.ContentDescriptorObject{cd}, nil
+	return []descriptorField{{nf, ty}}
 }
 
 func (c *StandardReflectorT) GetMethodResult(r reflect.Value, m reflect.Method, funcDecl *ast.FuncDecl) (cd meta_schema.ContentDescriptorObject, err error) {
 	if c.FnGetMethodResult != nil {
 		return c.FnGetMethodResult(r, m, funcDecl)
 	}
+	f, _ := c.methodResultField(m, funcDecl)
+	return buildContentDescriptorObject(c, r, m, f.field, f.ty)
+}
+
+// methodResultField returns the field and type of the result of a net/rpc method: its reply.
+func (c *StandardReflectorT) methodResultField(m reflect.Method, funcDecl *ast.FuncDecl) (descriptorField, bool) {
 	if funcDecl.Type.Params == nil {
 		panic("unreachable")
 	}
@@ -176,9 +218,7 @@ func (c *StandardReflectorT) GetMethodResult(r reflect.Value, m reflect.Method,
 	expandedFields := expandedFieldNamesFromList(funcDecl.Type.Params.List)
 
 	// We always want only the second param.
-	nf := expandedFields[1]
-	ty := m.Type.In(2)
-	return buildContentDescriptorObject(c, r, m, nf, ty)
+	return descriptorField{expandedFields[1], m.Type.In(2)}, true
 }
 
 func (c *StandardReflectorT) GetMethodDescription(r reflect.Value, m reflect.Method, funcDecl *ast.FuncDecl) (string, error) {
@@ -260,6 +300,9 @@ func (c *StandardReflectorT) GetMethodParamStructure(r reflect.Value, m reflect.
 	if c.FnGetMethodParamStructure != nil {
 		return c.FnGetMethodParamStructure(r, m, funcDecl)
 	}
+	if c.FlattenArgs && isFlattenableArg(m.Type.In(1)) {
+		return "by-name", nil
+	}
 	return "by-position", nil
 }
 
@@ -281,7 +324,7 @@ func (c *StandardReflectorT) GetMethodLinks(r reflect.Value, m reflect.Method, f
 	if c.FnGetMethodLinks != nil {
 		return c.FnGetMethodLinks(r, m, funcDecl)
 	}
-	return nil, nil
+	return directiveLinks(funcDecl), nil
 }
 
 func (c *StandardReflectorT) GetMethodExamples(r reflect.Value, m reflect.Method, funcDecl *ast.FuncDecl) (*meta_schema.MethodObjectExamples, error) {
@@ -330,7 +373,8 @@ func (c *StandardReflectorT) GetContentDescriptorRequired(r reflect.Value, m ref
 		return c.FnGetContentDescriptorRequired(r, m, field)
 	}
 	// The standard method signature pattern does not allow for variadic arguments.
-	return true, nil
+	// Only fields of flattened args have tags.
+	return isRequiredByTag(field), nil
 }
 
 func (c *StandardReflectorT) GetContentDescriptorDeprecated(r reflect.Value, m reflect.Method, field *ast.Field) (bool, error) {
@@ -367,17 +411,24 @@ func (c *StandardReflectorT) SchemaIgnoredTypes() []interface{} {
 	return nil
 }
 
+// SchemaTypeMap defaults to StandardSchemaTypeMap.
 func (c *StandardReflectorT) SchemaTypeMap() func(ty reflect.Type) *jsonschema.Type {
 	if c.FnSchemaTypeMap != nil {
 		return c.FnSchemaTypeMap()
 	}
-	return nil
+	return StandardSchemaTypeMap
 }
 
 func (c *StandardReflectorT) SchemaMutations(ty reflect.Type) []func(*spec.Schema) func(*spec.Schema) error {
 	if c.FnSchemaMutations != nil {
 		return c.FnSchemaMutations(ty)
 	}
+	if c.EncodingJSON {
+		return []func(*spec.Schema) func(*spec.Schema) error{
+			SchemaMutationExpand,
+			SchemaMutationRemoveDefinitionsField,
+		}
+	}
 	return []func(*spec.Schema) func(*spec.Schema) error{
 		SchemaMutationRequireDefaultOn,
 		SchemaMutationExpand,
@@ -385,13 +436,46 @@ func (c *StandardReflectorT) SchemaMutations(ty reflect.Type) []func(*spec.Schem
 	}
 }
 
+// SchemaExamples defaults to the examples of SchemaExamplesProvider types.
 func (c *StandardReflectorT) SchemaExamples(ty reflect.Type) (examples *meta_schema.Examples, err error) {
 	if c.FnSchemaExamples != nil {
 		return c.FnSchemaExamples(ty)
 	}
+	if provider, ok := schemaExamplesProviderOf(ty); ok {
+		return provider.OpenRPCSchemaExamples()
+	}
 	return nil, nil
 }
 
+func (c *StandardReflectorT) GetMethodExtensions(r reflect.Value, m reflect.Method, funcDecl *ast.FuncDecl) (Extensions, error) {
+	if c.FnGetMethodExtensions != nil {
+		return c.FnGetMethodExtensions(r, m, funcDecl)
+	}
+	return nil, nil
+}
+
+func (c *StandardReflectorT) GetContentDescriptorExtensions(r reflect.Value, m reflect.Method, field *ast.Field) (Extensions, error) {
+	if c.FnGetContentDescriptorExtensions != nil {
+		return c.FnGetContentDescriptorExtensions(r, m, field)
+	}
+	return nil, nil
+}
+
+func (c *StandardReflectorT) GetSchemaExtensions(r reflect.Value, m reflect.Method, field *ast.Field, ty reflect.Type) (Extensions, error) {
+	if c.FnGetSchemaExtensions != nil {
+		return c.FnGetSchemaExtensions(r, m, field, ty)
+	}
+	return nil, nil
+}
+
+// SchemaValidationTags defaults to StandardValidationTags.
+func (c *StandardReflectorT) SchemaValidationTags() ValidationTags {
+	if c.FnSchemaValidationTags != nil {
+		return c.FnSchemaValidationTags()
+	}
+	return StandardValidationTags
+}
+
 // ------------------------------------------------------------------------------
 
 func SchemaMutationRemoveDefinitionsField(root *spec.Schema) func (s *spec.Schema) error {
@@ -1,8 +1,11 @@
 package go_openrpc_reflect
 
 import (
+	"crypto/tls"
 	"encoding/json"
+	"go/ast"
 	"net"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"testing"
@@ -15,12 +18,64 @@ import (
 func TestStandardReflectorT_GetServers(t *testing.T) {
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	assert.NoError(t, err)
+	defer listener.Close()
 
 	getServersFn := StandardReflector.GetServers()
 	servers, err := getServersFn([]net.Listener{listener})
 	assert.Len(t, ([]meta_schema.ServerObject)(*servers), 1)
-	assert.Equal(t, string(*([]meta_schema.ServerObject)(*servers)[0].Name), "tcp")
-	assert.Equal(t, string(*([]meta_schema.ServerObject)(*servers)[0].Url), listener.Addr().String())
+	assert.Equal(t, string(*([]meta_schema.ServerObject)(*servers)[0].Name), "http")
+	assert.Equal(t, string(*([]meta_schema.ServerObject)(*servers)[0].Url), "http://"+listener.Addr().String())
+}
+
+func TestStandardReflectorT_GetServers_Inference(t *testing.T) {
+	hostname := publicHostname()
+
+	unspecified, err := net.Listen("tcp", "0.0.0.0:0")
+	if !assert.NoError(t, err) {
+		t.Fatal("listen error, fatal")
+	}
+	defer unspecified.Close()
+	_, port, _ := net.SplitHostPort(unspecified.Addr().String())
+
+	socket := filepath.Join(t.TempDir(), "node.ipc")
+	unix, err := net.Listen("unix", socket)
+	if !assert.NoError(t, err) {
+		t.Fatal("listen error, fatal")
+	}
+	defer unix.Close()
+
+	tlsListener := tls.NewListener(unspecified, &tls.Config{})
+
+	region := "eu"
+	cases := []struct {
+		listener net.Listener
+		url      string
+		name     string
+	}{
+		{unspecified, "http://" + net.JoinHostPort(hostname, port), "http"},
+		{unix, "unix://" + socket, "unix"},
+		{tlsListener, "https://" + net.JoinHostPort(hostname, port), "https"},
+		{&ServerListener{Listener: unspecified, Scheme: "ws", Host: "node.example.com", Path: "/ws", Name: "websocket"},
+			"ws://node.example.com:" + port + "/ws", "websocket"},
+		{&ServerListener{
+			Listener: tlsListener,
+			Host:     "{region}.example.com",
+			Variables: map[string]meta_schema.ServerObjectVariable{
+				"region": {Default: (*meta_schema.ServerObjectVariableDefault)(&region)},
+			},
+		}, "https://{region}.example.com:" + port, "https"},
+	}
+	for _, c := range cases {
+		server := listenerServer(c.listener)
+		assert.Equal(t, c.url, string(*server.Url))
+		assert.Equal(t, c.name, string(*server.Name))
+	}
+
+	server := listenerServer(cases[4].listener)
+	b, _ := json.Marshal(server)
+	testJSON(t, b, map[string]interface{}{
+		"variables.region.default": "eu",
+	})
 }
 
 func newStandardMethodTester() *MethodTester {
@@ -92,8 +147,8 @@ func TestStandardReflectorT_ReceiverMethods(t *testing.T) {
 		`methods.#(name=="CalculatorRPC.BigMul").params.0.name`:                     "arg",
 		`methods.#(name=="CalculatorRPC.BigMul").params.0.description`:              "BigMulArg",
 		`methods.#(name=="CalculatorRPC.BigMul").params.0.schema.type`:              "object",
-		`methods.#(name=="CalculatorRPC.BigMul").params.0.schema.properties.B.type`: "object",
-		`methods.#(name=="CalculatorRPC.BigMul").params.0.schema.properties.a.type`: "object",
+		`methods.#(name=="CalculatorRPC.BigMul").params.0.schema.properties.B.type`: "integer",
+		`methods.#(name=="CalculatorRPC.BigMul").params.0.schema.properties.a.type`: "integer",
 
 		`methods.#(name=="CalculatorRPC.HasBatteries").result.name`:        "reply",
 		`methods.#(name=="CalculatorRPC.HasBatteries").result.schema.type`: "boolean",
@@ -102,7 +157,7 @@ func TestStandardReflectorT_ReceiverMethods(t *testing.T) {
 
 		`methods.#(name=="CalculatorRPC.IsZero").params.0.name`:        "big.Int",
 		`methods.#(name=="CalculatorRPC.IsZero").params.0.description`: "big.Int",
-		`methods.#(name=="CalculatorRPC.IsZero").params.0.schema.type`: "object",
+		`methods.#(name=="CalculatorRPC.IsZero").params.0.schema.type`: "integer",
 		`methods.#(name=="CalculatorRPC.IsZero").result.name`:          "*IsZeroArg",
 	}
 
@@ -371,3 +426,28 @@ func TestStandardReflectorT_GetMethodResult(t *testing.T) {
 		testJSON(t, b, c.result)
 	}
 }
+
+func TestStandardReflectorT_GetMethodServers(t *testing.T) {
+	url := "https://archive.example.com"
+	reflector := &StandardReflectorT{}
+	reflector.FnGetMethodServers = func(r reflect.Value, m reflect.Method, funcDecl *ast.FuncDecl) (*meta_schema.Servers, error) {
+		if m.Name != "BigMul" {
+			return nil, nil
+		}
+		return &meta_schema.Servers{{Url: (*meta_schema.ServerObjectUrl)(&url)}}, nil
+	}
+
+	methods, err := reflector.ReceiverMethods("", &fakearithmetic.CalculatorRPC{})
+	if !assert.NoError(t, err) {
+		t.Fatal("receiver methods error, fatal")
+	}
+	for _, m := range methods {
+		if *m.Name != "CalculatorRPC.BigMul" {
+			assert.Nil(t, m.Servers, string(*m.Name))
+			continue
+		}
+		if assert.NotNil(t, m.Servers) {
+			assert.Equal(t, url, string(*(*m.Servers)[0].Url))
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package go_openrpc_reflect
+
+import (
+	"context"
+	"net"
+)
+
+// Transports name the ways in which a server is reached, eg. "http", "ws" or "ipc".
+// Receivers and listeners may be registered against transports, and a document
+// discovered for a transport (see ContextWithTransport) only includes those
+// registered against it, together with those registered against none.
+// A document discovered without a transport only includes those registered against none.
+
+// RegisterReceiverTransports registers a named receiver whose methods are served on the transports only.
+// Receivers registered without transports are served on all of them.
+func (d *Document) RegisterReceiverTransports(name string, receiver interface{}, transports ...string) {
+	d.registerReceiver(name, receiver, transports, nil)
+}
+
+func (d *Document) registerReceiver(name string, receiver interface{}, transports []string, listeners []net.Listener) {
+	d.receiverNames = append(d.receiverNames, name)
+	d.receivers = append(d.receivers, receiver)
+	d.receiverTransports = append(d.receiverTransports, transports)
+	d.receiverListeners = append(d.receiverListeners, listeners)
+}
+
+// RegisterListenerTransport registers a listener serving the transport.
+// Its server is only documented for the transport.
+func (d *Document) RegisterListenerTransport(listener net.Listener, transport string) {
+	d.listeners = append(d.listeners, listener)
+	d.listenerTransports = append(d.listenerTransports, transport)
+}
+
+type transportContextKey struct{}
+
+// ContextWithTransport returns a context for discovering the document of a transport,
+// eg. for a discovery request which arrived over it.
+func ContextWithTransport(ctx context.Context, transport string) context.Context {
+	return context.WithValue(ctx, transportContextKey{}, transport)
+}
+
+// TransportFromContext returns the transport of a context made by ContextWithTransport.
+func TransportFromContext(ctx context.Context) (transport string, ok bool) {
+	transport, ok = ctx.Value(transportContextKey{}).(string)
+	return
+}
+
+// onTransport tells whether something registered against the transports is served on the transport.
+// Something registered against no transports is served on all of them, and nothing else is served on
+// the empty transport, which is that of contexts without one.
+func onTransport(transports []string, transport string) bool {
+	if len(transports) == 0 {
+		return true
+	}
+	for _, t := range transports {
+		if t == transport {
+			return true
+		}
+	}
+	return false
+}
+
+// transportListeners returns the listeners serving the transport.
+func (d *Document) transportListeners(transport string) []net.Listener {
+	out := []net.Listener{}
+	for i, l := range d.listeners {
+		var transports []string
+		if t := d.listenerTransports[i]; t != "" {
+			transports = []string{t}
+		}
+		if onTransport(transports, transport) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+package go_openrpc_reflect
+
+import (
+	"encoding"
+	"encoding/json"
+	"math/big"
+	"net"
+	"reflect"
+	"time"
+
+	"github.com/alecthomas/jsonschema"
+)
+
+// SchemaTypeMap is the type of SchemaRegisterer.SchemaTypeMap's mapper: it returns the schema
+// of a Go type, or nil to leave the type to be reflected.
+type SchemaTypeMap func(ty reflect.Type) *jsonschema.Type
+
+// ComposeSchemaTypeMaps returns a map which tries each of the maps in order, eg.
+// ComposeSchemaTypeMaps(myTypes, StandardSchemaTypeMap) to override the standard library's mappings.
+func ComposeSchemaTypeMaps(maps ...SchemaTypeMap) SchemaTypeMap {
+	return func(ty reflect.Type) *jsonschema.Type {
+		for _, m := range maps {
+			if m == nil {
+				continue
+			}
+			if t := m(ty); t != nil {
+				return t
+			}
+		}
+		return nil
+	}
+}
+
+var (
+	durationType   = reflect.TypeOf(time.Duration(0))
+	timeType       = reflect.TypeOf(time.Time{})
+	bigIntType     = reflect.TypeOf(big.Int{})
+	bigFloatType   = reflect.TypeOf(big.Float{})
+	bigRatType     = reflect.TypeOf(big.Rat{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	jsonNumberType = reflect.TypeOf(json.Number(""))
+	ipType         = reflect.TypeOf(net.IP{})
+
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// StandardSchemaTypeMap maps standard library types to the JSON encoding/json gives them:
+//
+//	time.Time                date-time strings
+//	time.Duration            integers, of nanoseconds
+//	big.Int                  integers
+//	big.Float, big.Rat       numeric strings, eg. "1.5" and "3/2"
+//	json.Number              numbers
+//	[]byte and named kin     base64 strings
+//	net.IP                   ipv4 or ipv6 strings
+//	json.RawMessage          any JSON
+//
+// The sql.Null types are left to be reflected, as encoding/json marshals them as objects of their fields.
+// So does it url.URL, which isn't mapped to a uri string here, though alecthomas/jsonschema documents it as one.
+// It is the default SchemaTypeMap of the StandardReflector.
+func StandardSchemaTypeMap(ty reflect.Type) *jsonschema.Type {
+	switch ty {
+	case timeType:
+		return &jsonschema.Type{Type: "string", Format: "date-time"}
+	case durationType:
+		return &jsonschema.Type{Type: "integer", Description: "A duration in nanoseconds."}
+	case bigIntType:
+		return &jsonschema.Type{Type: "integer"}
+	case bigFloatType:
+		return &jsonschema.Type{Type: "string", Pattern: `^[-+]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eEpP][-+]?[0-9]+)?$`}
+	case bigRatType:
+		return &jsonschema.Type{Type: "string", Pattern: `^[-+]?[0-9]+(/[0-9]+)?$`}
+	case jsonNumberType:
+		return &jsonschema.Type{Type: "number"}
+	case ipType:
+		return &jsonschema.Type{Type: "string", AnyOf: []*jsonschema.Type{{Format: "ipv4"}, {Format: "ipv6"}}}
+	case rawMessageType:
+		return &jsonschema.Type{}
+	}
+	// encoding/json encodes byte slices as base64, unless they marshal themselves.
+	if ty.Kind() == reflect.Slice && ty.Elem().Kind() == reflect.Uint8 &&
+		!ty.Implements(jsonMarshalerType) && !ty.Implements(textMarshalerType) &&
+		!reflect.PtrTo(ty).Implements(jsonMarshalerType) && !reflect.PtrTo(ty).Implements(textMarshalerType) {
+		return &jsonschema.Type{Type: "string", Media: &jsonschema.Type{BinaryEncoding: "base64"}}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"math/big"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/stretchr/testify/assert"
+)
+
+type typeMapBytes []byte
+
+type TypeMapStruct struct {
+	Time     time.Time       `json:"time"`
+	Duration time.Duration   `json:"duration"`
+	Int      *big.Int        `json:"int"`
+	Float    *big.Float      `json:"float"`
+	Rat      big.Rat         `json:"rat"`
+	Number   json.Number     `json:"number"`
+	Bytes    typeMapBytes    `json:"bytes"`
+	IP       net.IP          `json:"ip"`
+	Raw      json.RawMessage `json:"raw"`
+}
+
+func TestStandardSchemaTypeMap(t *testing.T) {
+	schema, err := buildJSONSchemaObject(StandardReflector, reflect.Value{}, reflect.Method{}, nil, reflect.TypeOf(TypeMapStruct{}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, _ := json.Marshal(schema)
+	t.Log(string(b))
+	testJSON(t, b, map[string]interface{}{
+		"properties.time.type":                  "string",
+		"properties.time.format":                "date-time",
+		"properties.duration.type":              "integer",
+		"properties.int.type":                   "integer",
+		"properties.float.type":                 "string",
+		"properties.float.pattern":              `^[-+]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eEpP][-+]?[0-9]+)?$`,
+		"properties.rat.pattern":                `^[-+]?[0-9]+(/[0-9]+)?$`,
+		"properties.number.type":                "number",
+		"properties.bytes.type":                 "string",
+		"properties.bytes.media.binaryEncoding": "base64",
+		"properties.ip.anyOf.#":                 float64(2),
+		"properties.ip.anyOf.1.format":          "ipv6",
+		"properties.raw":                        map[string]interface{}{},
+	})
+}
+
+func TestComposeSchemaTypeMaps(t *testing.T) {
+	reflector := &StandardReflectorT{}
+	reflector.FnSchemaTypeMap = func() func(ty reflect.Type) *jsonschema.Type {
+		return ComposeSchemaTypeMaps(func(ty reflect.Type) *jsonschema.Type {
+			if ty == durationType {
+				return &jsonschema.Type{Type: "string", Pattern: `^[0-9]+(ns|us|ms|s|m|h)$`}
+			}
+			return nil
+		}, StandardSchemaTypeMap)
+	}
+	schema, err := buildJSONSchemaObject(reflector, reflect.Value{}, reflect.Method{}, nil, reflect.TypeOf(TypeMapStruct{}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, _ := json.Marshal(schema)
+	testJSON(t, b, map[string]interface{}{
+		"properties.duration.type": "string",
+		"properties.int.type":      "integer",
+	})
+}
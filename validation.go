@@ -0,0 +1,313 @@
+package go_openrpc_reflect
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/go-openapi/spec"
+)
+
+// SchemaValidationTagsRegisterer is implemented by reflectors which translate the validation tags of struct fields,
+// eg. validate:"min=1", to keywords of their schemas.
+type SchemaValidationTagsRegisterer interface {
+	SchemaValidationTags() ValidationTags
+}
+
+// ValidationField is the schema of a struct field, constrained by the rules of its validation tags.
+type ValidationField struct {
+	// Field is the tagged struct field.
+	Field reflect.StructField
+
+	// Name is the field's property name.
+	Name string
+
+	// Type is the type constrained, ie. the field's, or its elements' following a dive rule,
+	// with pointers dereferenced.
+	Type reflect.Type
+
+	// Schema is the schema of Type.
+	Schema *jsonschema.Type
+
+	// Parent is the schema of the field's struct, or nil following a dive rule.
+	Parent *jsonschema.Type
+}
+
+// SetKeyword sets a JSON Schema keyword of the field's schema.
+// It sets keywords which alecthomas/jsonschema types as integers or booleans, eg. minimum, as their values.
+func (f *ValidationField) SetKeyword(key string, value interface{}) {
+	if f.Schema.Extras == nil {
+		f.Schema.Extras = map[string]interface{}{}
+	}
+	f.Schema.Extras[key] = value
+}
+
+// ValidationRule translates a validation tag's rule, eg. min=1 of validate:"min=1", to JSON Schema keywords.
+// It's given the rule's param, eg. "1", which is empty for rules without one.
+type ValidationRule func(field *ValidationField, param string)
+
+// ValidationTags maps struct tag names, eg. "validate", to the translations of their rules, keyed by rule name.
+// Rules without translations are ignored.
+type ValidationTags map[string]map[string]ValidationRule
+
+// StandardValidationRules translates the common rules of github.com/go-playground/validator:
+//
+//	required                   required properties
+//	min, max, len              minimum and maximum, or the min and max lengths of strings, arrays and objects
+//	gt, gte, lt, lte           exclusive and inclusive minimums and maximums, or lengths, but of floats inclusive only
+//	oneof                      enum
+//	unique                     uniqueItems
+//	email, url, uri, uuid...   format
+//	alpha, alphanum, numeric,
+//	hexadecimal, startswith,
+//	endswith, contains         pattern
+//
+// and the dive rule, which applies the rules following it to array items and object values.
+var StandardValidationRules = map[string]ValidationRule{
+	"required": validationRequired,
+	"min":      validationBound("minimum", "minLength", "minItems", "minProperties", 0),
+	"max":      validationBound("maximum", "maxLength", "maxItems", "maxProperties", 0),
+	"gte":      validationBound("minimum", "minLength", "minItems", "minProperties", 0),
+	"lte":      validationBound("maximum", "maxLength", "maxItems", "maxProperties", 0),
+	"gt":       validationBound("minimum", "minLength", "minItems", "minProperties", 1),
+	"lt":       validationBound("maximum", "maxLength", "maxItems", "maxProperties", -1),
+	"len": func(field *ValidationField, param string) {
+		validationBound("minimum", "minLength", "minItems", "minProperties", 0)(field, param)
+		validationBound("maximum", "maxLength", "maxItems", "maxProperties", 0)(field, param)
+	},
+	"oneof":       validationOneOf,
+	"unique":      func(field *ValidationField, param string) { field.Schema.UniqueItems = true },
+	"email":       validationFormat("email"),
+	"url":         validationFormat("uri"),
+	"uri":         validationFormat("uri"),
+	"uuid":        validationFormat("uuid"),
+	"uuid4":       validationFormat("uuid"),
+	"ipv4":        validationFormat("ipv4"),
+	"ipv6":        validationFormat("ipv6"),
+	"hostname":    validationFormat("hostname"),
+	"datetime":    validationFormat("date-time"),
+	"alpha":       validationPattern(`^[a-zA-Z]+$`),
+	"alphanum":    validationPattern(`^[a-zA-Z0-9]+$`),
+	"numeric":     validationPattern(`^[-+]?[0-9]+(\.[0-9]+)?$`),
+	"hexadecimal": validationPattern(`^(0[xX])?[0-9a-fA-F]+$`),
+	"startswith": func(field *ValidationField, param string) {
+		validationPattern("^"+regexp.QuoteMeta(param))(field, param)
+	},
+	"endswith": func(field *ValidationField, param string) {
+		validationPattern(regexp.QuoteMeta(param)+"$")(field, param)
+	},
+	"contains": func(field *ValidationField, param string) {
+		validationPattern(regexp.QuoteMeta(param))(field, param)
+	},
+}
+
+// StandardValidationTags translates the rules of go-playground/validator's validate tags, and of gin's binding tags.
+// It is the default SchemaValidationTags of the StandardReflector.
+var StandardValidationTags = ValidationTags{
+	"validate": StandardValidationRules,
+	"binding":  StandardValidationRules,
+}
+
+func validationRequired(field *ValidationField, param string) {
+	if field.Parent == nil {
+		return
+	}
+	for _, r := range field.Parent.Required {
+		if r == field.Name {
+			return
+		}
+	}
+	field.Parent.Required = append(field.Parent.Required, field.Name)
+}
+
+// validationBound returns a rule setting a bound's keyword for the kind of the field's type.
+// Exclusive bounds are given an offset, eg. gt=2 is a minimum, or min length, of 3, as the schemas are mutated
+// as draft 4 schemas, whose exclusive bounds are booleans, but documented as draft 7 schemas, whose are numbers.
+// So exclusive bounds of floats are left out.
+func validationBound(number, length, items, properties string, offset int) ValidationRule {
+	return func(field *ValidationField, param string) {
+		switch field.Type.Kind() {
+		case reflect.Float32, reflect.Float64:
+			if n, err := strconv.ParseFloat(param, 64); err == nil && offset == 0 {
+				field.SetKeyword(number, n)
+			}
+			return
+		}
+		n, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return
+		}
+		n += int64(offset)
+		switch field.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			field.SetKeyword(number, n)
+		case reflect.String:
+			field.SetKeyword(length, n)
+		case reflect.Slice, reflect.Array:
+			field.SetKeyword(items, n)
+		case reflect.Map:
+			field.SetKeyword(properties, n)
+		}
+	}
+}
+
+// validationOneOfParam matches the values of oneof params, which are separated by spaces, or quoted by single quotes.
+var validationOneOfParam = regexp.MustCompile(`'[^']*'|\S+`)
+
+func validationOneOf(field *ValidationField, param string) {
+	enum := []interface{}{}
+	for _, v := range validationOneOfParam.FindAllString(param, -1) {
+		v = strings.Trim(v, "'")
+		switch field.Type.Kind() {
+		case reflect.String:
+			enum = append(enum, v)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return
+			}
+			enum = append(enum, n)
+		default:
+			return
+		}
+	}
+	field.Schema.Enum = enum
+}
+
+func validationFormat(format string) ValidationRule {
+	return func(field *ValidationField, param string) {
+		field.Schema.Format = format
+	}
+}
+
+func validationPattern(pattern string) ValidationRule {
+	return func(field *ValidationField, param string) {
+		field.Schema.Pattern = pattern
+	}
+}
+
+// applyValidationTags translates the validation tags of the fields of the structs reflected in the schema
+// to keywords of their properties' schemas. The properties' fields are looked up by fields.
+// With requireDefault, ie. SchemaMutationRequireDefaultOn, the properties required by tags add to those required
+// by default, all of them, rather than replacing them.
+func applyValidationTags(schema *jsonschema.Schema, ty reflect.Type, tags ValidationTags, fields func(reflect.Type) []reflectedField, requireDefault bool) {
+	if len(tags) == 0 {
+		return
+	}
+	visited := map[reflect.Type]bool{}
+	var visit func(t reflect.Type)
+	visit = func(t reflect.Type) {
+		t = elemType(t)
+		if t.Kind() != reflect.Struct || t.Name() == "" || visited[t] {
+			return
+		}
+		visited[t] = true
+		def, ok := schema.Definitions[t.Name()]
+		if !ok || def.Properties == nil {
+			return
+		}
+		required := len(def.Required) > 0
+		for _, f := range fields(t) {
+			if v, ok := def.Properties.Get(f.name); ok {
+				applyFieldValidationTags(def, f.name, f.field, nonNullSchema(v.(*jsonschema.Type)), tags)
+			}
+			visit(f.field.Type)
+		}
+		if requireDefault && !required && len(def.Required) > 0 {
+			def.Required = append([]string{}, def.Properties.Keys()...)
+		}
+	}
+	visit(ty)
+}
+
+// requiresDefault tells whether the schema mutations include SchemaMutationRequireDefaultOn.
+func requiresDefault(mutations []func(*spec.Schema) func(*spec.Schema) error) bool {
+	requireDefaultOn := reflect.ValueOf(SchemaMutationRequireDefaultOn).Pointer()
+	for _, m := range mutations {
+		if reflect.ValueOf(m).Pointer() == requireDefaultOn {
+			return true
+		}
+	}
+	return false
+}
+
+func applyFieldValidationTags(parent *jsonschema.Type, name string, f reflect.StructField, schema *jsonschema.Type, tags ValidationTags) {
+	for tag, rules := range tags {
+		field := &ValidationField{Field: f, Name: name, Type: derefType(f.Type), Schema: schema, Parent: parent}
+		applyValidationRules(field, f.Tag.Get(tag), rules)
+	}
+}
+
+// applyValidationRules translates the rules of a validation tag's value, eg. "required,min=1".
+func applyValidationRules(field *ValidationField, tag string, rules map[string]ValidationRule) {
+	for _, rule := range strings.Split(tag, ",") {
+		ruleName, param := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			ruleName, param = rule[:i], rule[i+1:]
+		}
+		if ruleName == "dive" {
+			// The following rules constrain the elements.
+			switch {
+			case field.Schema.Items != nil:
+				field.Schema = field.Schema.Items
+			case field.Schema.PatternProperties[".*"] != nil:
+				field.Schema = field.Schema.PatternProperties[".*"]
+			default:
+				return
+			}
+			field.Type = derefType(field.Type.Elem())
+			field.Parent = nil
+			continue
+		}
+		if translate, ok := rules[ruleName]; ok {
+			translate(field, param)
+		}
+	}
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// reflectedField is a struct field, named by its property as alecthomas/jsonschema names it.
+type reflectedField struct {
+	name  string
+	field reflect.StructField
+}
+
+// reflectedStructFields returns the fields alecthomas/jsonschema reflects as properties of the struct,
+// including those of embedded structs without json tags.
+func reflectedStructFields(t reflect.Type) (out []reflectedField) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		jsonTags, exist := f.Tag.Lookup("json")
+		if !exist {
+			jsonTags = f.Tag.Get("yaml")
+		}
+		name := strings.Split(jsonTags, ",")[0]
+		if name == "-" || strings.Split(f.Tag.Get("jsonschema"), ",")[0] == "-" {
+			continue
+		}
+		if f.Anonymous && !exist {
+			if et := derefType(f.Type); et.Kind() == reflect.Struct {
+				out = append(out, reflectedStructFields(et)...)
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		out = append(out, reflectedField{name: name, field: f})
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+type ValidationAddress struct {
+	City string `json:"city" validate:"required,alpha"`
+	Zip  string `json:"zip" binding:"len=5,numeric"`
+}
+
+type ValidationSignup struct {
+	Email    string               `json:"email" validate:"required,email"`
+	Name     *string              `json:"name" validate:"omitempty,min=1,max=100"`
+	Age      int                  `json:"age" validate:"gte=18,lt=130"`
+	Plan     string               `json:"plan" validate:"oneof=free 'pro plus'"`
+	Seats    uint                 `json:"seats" validate:"oneof=1 5 10"`
+	Tags     []string             `json:"tags" validate:"min=1,unique,dive,startswith=#"`
+	Labels   map[string]string    `json:"labels" validate:"max=3,dive,hexadecimal"`
+	Address  ValidationAddress    `json:"address" validate:"required"`
+	Previous []*ValidationAddress `json:"previous"`
+	Ignored  string               `json:"-" validate:"required"`
+}
+
+func TestValidationTags(t *testing.T) {
+	schema, err := buildJSONSchemaObject(StandardReflector, reflect.Value{}, reflect.Method{}, nil, reflect.TypeOf(ValidationSignup{}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, _ := json.Marshal(schema)
+	t.Log(string(b))
+	testJSON(t, b, map[string]interface{}{
+		"required.#":                                    float64(9),
+		"required.0":                                    "email",
+		"required.1":                                    "name",
+		"required.7":                                    "address",
+		"properties.email.format":                       "email",
+		"properties.name.minLength":                     float64(1),
+		"properties.name.maxLength":                     float64(100),
+		"properties.age.minimum":                        float64(18),
+		"properties.age.maximum":                        float64(129),
+		"properties.plan.enum.#":                        float64(2),
+		"properties.plan.enum.1":                        "pro plus",
+		"properties.seats.enum.2":                       float64(10),
+		"properties.tags.minItems":                      float64(1),
+		"properties.tags.uniqueItems":                   true,
+		"properties.tags.items.pattern":                 "^#",
+		"properties.labels.maxProperties":               float64(3),
+		"properties.labels.patternProperties.*.pattern": "^(0[xX])?[0-9a-fA-F]+$",
+		"properties.address.required.#":                 float64(2),
+		"properties.address.properties.city.pattern":    "^[a-zA-Z]+$",
+		"properties.address.properties.zip.minLength":   float64(5),
+		"properties.address.properties.zip.maxLength":   float64(5),
+		"properties.previous.items.required.0":          "city",
+	})
+}
+
+func TestValidationTags_Required(t *testing.T) {
+	reflector := &StandardReflectorT{EncodingJSON: true}
+	schema, err := buildJSONSchemaObject(reflector, reflect.Value{}, reflect.Method{}, nil, reflect.TypeOf(ValidationSignup{}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, _ := json.Marshal(schema)
+	testJSON(t, b, map[string]interface{}{
+		"required.#":                    float64(8),
+		"required.6":                    "address",
+		"properties.address.required.#": float64(2),
+	})
+
+	// Without SchemaMutationRequireDefaultOn, only the properties required by tags are.
+	reflector = &StandardReflectorT{}
+	reflector.FnSchemaMutations = func(ty reflect.Type) []func(*spec.Schema) func(*spec.Schema) error {
+		return []func(*spec.Schema) func(*spec.Schema) error{SchemaMutationExpand, SchemaMutationRemoveDefinitionsField}
+	}
+	schema, err = buildJSONSchemaObject(reflector, reflect.Value{}, reflect.Method{}, nil, reflect.TypeOf(ValidationSignup{}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, _ = json.Marshal(schema)
+	testJSON(t, b, map[string]interface{}{
+		"required.#":                    float64(2),
+		"required.0":                    "email",
+		"required.1":                    "address",
+		"properties.address.required.#": float64(1),
+	})
+}
+
+func TestValidationTags_Override(t *testing.T) {
+	reflector := &StandardReflectorT{}
+	reflector.FnSchemaValidationTags = func() ValidationTags {
+		return ValidationTags{"validate": {
+			"email": func(field *ValidationField, param string) {
+				field.Schema.Format = "idn-email"
+			},
+		}}
+	}
+	schema, err := buildJSONSchemaObject(reflector, reflect.Value{}, reflect.Method{}, nil, reflect.TypeOf(ValidationSignup{}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, _ := json.Marshal(schema)
+	testJSON(t, b, map[string]interface{}{
+		"properties.email.format":                    "idn-email",
+		"properties.age.minimum":                     nil,
+		"properties.address.properties.city.pattern": nil,
+	})
+}
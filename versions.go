@@ -0,0 +1,201 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/schemajson"
+	meta_schema "github.com/open-rpc/meta-schema"
+)
+
+// OpenRPC specification versions which documents can target.
+const (
+	// OpenRPCVersion1_2 is the default version, that of the meta_schema types.
+	OpenRPCVersion1_2 = meta_schema.OpenrpcEnum0
+	// OpenRPCVersion1_3 adds notifications: methods without a result, whose example pairings have none either.
+	// Documents targeting it are 1.2 documents which may have notifications, which is all of 1.3 this package supports.
+	OpenRPCVersion1_3 meta_schema.Openrpc = "1.3.2"
+)
+
+// WithVersion sets the OpenRPC version of the document, which must be a 1.2 or 1.3 version.
+// Documents targeting a version are validated against it when discovered; see ValidateDocument.
+func (d *Document) WithVersion(version meta_schema.Openrpc) *Document {
+	d.version = version
+	return d
+}
+
+// allowsNotifications tells whether a version allows methods without results.
+func allowsNotifications(version meta_schema.Openrpc) bool {
+	return strings.HasPrefix(string(version), "1.3.")
+}
+
+// notificationResults documents the results of notification methods, which reflectors
+// build without results, as null for versions without notifications.
+func notificationResults(version meta_schema.Openrpc, methods []meta_schema.MethodObject) {
+	if allowsNotifications(version) {
+		return
+	}
+	for i := range methods {
+		if methods[i].Result == nil {
+			cd := nullContentDescriptor
+			methods[i].Result = &meta_schema.MethodObjectResult{ContentDescriptorObject: &cd}
+		}
+	}
+}
+
+// ValidationError lists the ways in which a document doesn't conform to its OpenRPC version.
+type ValidationError struct {
+	Version meta_schema.Openrpc
+	// Problems are described by the JSON pointers of the objects they concern, eg. "/methods/0/result: missing".
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("document does not conform to OpenRPC %s: %s", e.Version, strings.Join(e.Problems, "; "))
+}
+
+// metaSchemas are the meta-schemas of the OpenRPC versions documents can target, in their generic form,
+// by version prefix.
+var metaSchemas = []struct {
+	prefix string
+	schema schemajson.Schema
+}{
+	{"1.2.", metaSchema1_2()},
+	{"1.3.", metaSchema1_2Notifications()},
+}
+
+// metaSchema1_2 returns the 1.2 meta-schema, that of the meta_schema types.
+func metaSchema1_2() schemajson.Schema {
+	out := schemajson.Schema{}
+	if err := json.Unmarshal([]byte(meta_schema.RawOpenrpc_document), &out); err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// metaSchema1_2Notifications returns the meta-schema of documents targeting 1.3: the 1.2 meta-schema,
+// with 1.3 versions, and without requiring the results of methods and example pairings, ie. allowing notifications.
+// It isn't the published 1.3 meta-schema, whose other changes documents targeting 1.3 don't make.
+func metaSchema1_2Notifications() schemajson.Schema {
+	out := metaSchema1_2()
+	definitions := schemajson.FromValue(out["definitions"])
+	definitions["openrpc"] = map[string]interface{}{"title": "openrpc", "type": "string", "pattern": `^1\.3\.\d+$`}
+	for _, name := range []string{"methodObject", "examplePairingObject"} {
+		schemajson.FromValue(definitions[name])["required"] = []interface{}{"name", "params"}
+	}
+	return out
+}
+
+// ValidateDocument checks that a document conforms to the OpenRPC version it declares,
+// returning a *ValidationError if it doesn't.
+// It validates the document against the version's meta-schema, and checks what the meta-schema can't express:
+// unique method and param names, and required params preceding optional ones.
+// Documents declaring 1.3 versions are validated as 1.2 documents which may have notifications,
+// as this package documents them, rather than against the published 1.3 meta-schema.
+func ValidateDocument(doc *meta_schema.OpenrpcDocument) error {
+	var version meta_schema.Openrpc
+	if doc.Openrpc != nil {
+		version = *doc.Openrpc
+	}
+	v := &ValidationError{Version: version}
+	problem := func(pointer, format string, args ...interface{}) {
+		message := fmt.Sprintf(format, args...)
+		if pointer != "" {
+			message = pointer + ": " + message
+		}
+		v.Problems = append(v.Problems, message)
+	}
+
+	var schema schemajson.Schema
+	for _, m := range metaSchemas {
+		if strings.HasPrefix(string(version), m.prefix) {
+			schema = m.schema
+			break
+		}
+	}
+	if schema == nil {
+		problem("/openrpc", "unsupported version %q", version)
+		return v.orNil()
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return err
+	}
+	dropNulls(generic)
+	resolve := schemajson.DefinitionsResolver(schemajson.FromValue(schema["definitions"]))
+	for _, err := range schemajson.Validate(schema, generic, resolve) {
+		verr := err.(*schemajson.ValidationError)
+		problem(verr.Pointer, "%s", verr.Message)
+	}
+
+	if doc.Methods == nil {
+		return v.orNil()
+	}
+	names := map[string]bool{}
+	for i, m := range *doc.Methods {
+		pointer := fmt.Sprintf("/methods/%d", i)
+		if m.Name != nil {
+			if names[string(*m.Name)] {
+				problem(pointer+"/name", "duplicate method name %q", *m.Name)
+			}
+			names[string(*m.Name)] = true
+		}
+		if m.Params == nil {
+			continue
+		}
+		params := map[string]bool{}
+		optional := false
+		for j, p := range *m.Params {
+			cd := p.ContentDescriptorObject
+			if cd == nil {
+				continue
+			}
+			ppointer := fmt.Sprintf("%s/params/%d", pointer, j)
+			if cd.Name != nil {
+				if params[string(*cd.Name)] {
+					problem(ppointer+"/name", "duplicate param name %q", *cd.Name)
+				}
+				params[string(*cd.Name)] = true
+			}
+			required := cd.Required != nil && bool(*cd.Required)
+			if required && optional && (m.ParamStructure == nil || *m.ParamStructure != "by-name") {
+				problem(ppointer, "required param follows an optional param")
+			}
+			optional = optional || !required
+		}
+	}
+	return v.orNil()
+}
+
+// dropNulls removes the null properties of a document's objects: the meta_schema types marshal
+// their nil required fields, eg. a notification's result, as null, which is taken to be absent.
+// Example values are kept, as null is a value.
+func dropNulls(v interface{}) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, e := range vv {
+			if e == nil && k != "value" {
+				delete(vv, k)
+				continue
+			}
+			dropNulls(e)
+		}
+	case []interface{}:
+		for _, e := range vv {
+			dropNulls(e)
+		}
+	}
+}
+
+func (e *ValidationError) orNil() error {
+	if len(e.Problems) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,115 @@
+package go_openrpc_reflect
+
+import (
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"reflect"
+	"testing"
+
+	"github.com/etclabscore/go-openrpc-reflect/internal/fakearithmetic"
+	meta_schema "github.com/open-rpc/meta-schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func newNotificationDocument() *Document {
+	reflector := &StandardReflectorT{}
+	reflector.FnGetMethodResult = func(r reflect.Value, m reflect.Method, funcDecl *ast.FuncDecl) (meta_schema.ContentDescriptorObject, error) {
+		if m.Name == "Div" {
+			// A notification.
+			return meta_schema.ContentDescriptorObject{}, nil
+		}
+		return StandardReflector.GetMethodResult(r, m, funcDecl)
+	}
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(reflector)
+	d.RegisterReceiver(new(fakearithmetic.CalculatorRPC))
+	return d
+}
+
+func TestDocument_WithVersion(t *testing.T) {
+	cases := []struct {
+		version meta_schema.Openrpc
+		want    map[string]interface{}
+	}{
+		{"", map[string]interface{}{
+			"openrpc":               "1.2.6",
+			"methods.2.name":        "CalculatorRPC.Div",
+			"methods.2.result.name": "Null",
+		}},
+		{OpenRPCVersion1_2, map[string]interface{}{
+			"openrpc":               "1.2.6",
+			"methods.2.result.name": "Null",
+		}},
+		{OpenRPCVersion1_3, map[string]interface{}{
+			"openrpc":               "1.3.2",
+			"methods.2.name":        "CalculatorRPC.Div",
+			"methods.2.result":      nil,
+			"methods.0.result.name": "reply",
+		}},
+	}
+	for _, c := range cases {
+		out, err := newNotificationDocument().WithVersion(c.version).Discover()
+		if !assert.NoError(t, err, string(c.version)) {
+			continue
+		}
+		b, _ := json.Marshal(out)
+		testJSON(t, b, c.want)
+	}
+
+	_, err := newNotificationDocument().WithVersion("2.0.0").Discover()
+	var verr *ValidationError
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Equal(t, []string{`/openrpc: unsupported version "2.0.0"`}, verr.Problems)
+	}
+}
+
+func TestValidateDocument(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(EthereumReflector)
+	d.RegisterReceiverName("order", &linkOrderService{})
+	doc, err := d.Discover()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.NoError(t, ValidateDocument(doc))
+
+	// order_cancelOrder(id, reason): make reason required, and id optional and named reason.
+	methods := *doc.Methods
+	id, reason := *(*methods[0].Params)[0].ContentDescriptorObject, *(*methods[0].Params)[1].ContentDescriptorObject
+	required, optional := true, false
+	id.Name, id.Required = reason.Name, (*meta_schema.ContentDescriptorObjectRequired)(&optional)
+	reason.Required = (*meta_schema.ContentDescriptorObjectRequired)(&required)
+	methods[0].Params = &meta_schema.MethodObjectParams{{ContentDescriptorObject: &id}, {ContentDescriptorObject: &reason}}
+	methods[1].Name = methods[0].Name
+	methods[2].Result = nil
+	paramStructure := meta_schema.MethodObjectParamStructure("by-nothing")
+	methods[3].ParamStructure = &paramStructure
+	doc.Info = nil
+
+	err = ValidateDocument(doc)
+	var verr *ValidationError
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Equal(t, []string{
+			`missing required property "info"`,
+			`/methods/2: missing required property "result"`,
+			"/methods/3/paramStructure: value must be one of [by-position by-name either]",
+			`/methods/0/params/1/name: duplicate param name "reason"`,
+			"/methods/0/params/1: required param follows an optional param",
+			`/methods/1/name: duplicate method name "order_cancelOrder"`,
+		}, verr.Problems)
+	}
+
+	// 1.3 documents needn't have results.
+	version := OpenRPCVersion1_3
+	doc.Openrpc = &version
+	err = ValidateDocument(doc)
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Len(t, verr.Problems, 5)
+		assert.NotContains(t, verr.Problems, `/methods/2: missing required property "result"`)
+	}
+
+	version = "1.3.x"
+	err = ValidateDocument(doc)
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Contains(t, verr.Problems, `/openrpc: value "1.3.x" does not match the pattern "^1\\.3\\.\\d+$"`)
+	}
+}
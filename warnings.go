@@ -0,0 +1,199 @@
+package go_openrpc_reflect
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+	"strings"
+
+	"github.com/alecthomas/jsonschema"
+)
+
+// SchemaWarning describes a schema which couldn't be built faithfully, eg. of a type unsupported by JSON Schema,
+// documented by a fallback schema, or of a json.Marshaler whose JSON couldn't be inferred.
+type SchemaWarning struct {
+	// Receiver is the type of the method's receiver, eg. *calculator.Calculator.
+	Receiver string
+	Method   string
+	// Param is the name of the method's param or result, or its type if it's unnamed.
+	Param   string
+	Type    reflect.Type
+	Message string
+}
+
+func (w SchemaWarning) String() string {
+	where := w.Method
+	if w.Receiver != "" {
+		where = w.Receiver + "." + where
+	}
+	if w.Param != "" {
+		where += " " + w.Param
+	}
+	return fmt.Sprintf("%s: %v: %s", strings.TrimSpace(where), w.Type, w.Message)
+}
+
+// SchemaError is returned by strict reflectors in place of a SchemaWarning, see StandardReflectorT.Strict.
+type SchemaError struct {
+	SchemaWarning
+}
+
+func (e *SchemaError) Error() string {
+	return "schema: " + e.SchemaWarning.String()
+}
+
+// schemaWarner is implemented by reflectors collecting SchemaWarnings.
+type schemaWarner interface {
+	warnSchema(w SchemaWarning)
+}
+
+// schemaWarningsRegisterer is implemented by reflectors which collect the SchemaWarnings of a document's discovery,
+// as the default reflectors do: withSchemaWarnings returns a copy of the reflector collecting them in warnings.
+type schemaWarningsRegisterer interface {
+	withSchemaWarnings(warnings *schemaWarnings) ReceiverRegisterer
+}
+
+// strictSchemaRegisterer is implemented by reflectors which may fail on SchemaWarnings,
+// see StandardReflectorT.Strict.
+type strictSchemaRegisterer interface {
+	strictSchemas() bool
+}
+
+func (c *StandardReflectorT) strictSchemas() bool {
+	return c.Strict
+}
+
+func (c *StandardReflectorT) warnSchema(w SchemaWarning) {
+	c.warnings.add(w)
+}
+
+func (c *StandardReflectorT) withSchemaWarnings(warnings *schemaWarnings) ReceiverRegisterer {
+	out := *c
+	out.warnings = warnings
+	return &out
+}
+
+func (e *EthereumReflectorT) withSchemaWarnings(warnings *schemaWarnings) ReceiverRegisterer {
+	out := *e
+	out.warnings = warnings
+	return &out
+}
+
+func (g *GorillaReflectorT) withSchemaWarnings(warnings *schemaWarnings) ReceiverRegisterer {
+	out := *g
+	out.warnings = warnings
+	return &out
+}
+
+// schemaWarnings collects unique SchemaWarnings, in the order they're warned about.
+// A nil *schemaWarnings drops them.
+type schemaWarnings struct {
+	seen     map[SchemaWarning]bool
+	warnings []SchemaWarning
+}
+
+func (s *schemaWarnings) add(w SchemaWarning) {
+	if s == nil || s.seen[w] {
+		return
+	}
+	if s.seen == nil {
+		s.seen = map[SchemaWarning]bool{}
+	}
+	s.seen[w] = true
+	s.warnings = append(s.warnings, w)
+}
+
+// withSchemaWarnings returns a copy of the reflector collecting SchemaWarnings in warnings, or the reflector itself
+// if it doesn't collect them. Copies which aren't of the reflector's type, eg. of a StandardReflectorT embedded
+// by another reflector, are not used, as they would lose the reflector's methods.
+func withSchemaWarnings(reflector ReceiverRegisterer, warnings *schemaWarnings) ReceiverRegisterer {
+	registerer, ok := reflector.(schemaWarningsRegisterer)
+	if !ok {
+		return reflector
+	}
+	out := registerer.withSchemaWarnings(warnings)
+	if reflect.TypeOf(out) != reflect.TypeOf(reflector) {
+		return reflector
+	}
+	return out
+}
+
+// SchemaWarnings returns the warnings of the schemas of the document's last discovery,
+// if its reflector collects them, as the default reflectors do.
+func (d *Document) SchemaWarnings() []SchemaWarning {
+	d.schemaWarningsMu.Lock()
+	defer d.schemaWarningsMu.Unlock()
+	return append([]SchemaWarning{}, d.schemaWarnings...)
+}
+
+// schemaIssues collects the warnings of building the schema of a method's param or result.
+// Warnings are reported to the registerer as they're collected, unless it's strict,
+// in which case the first is returned as a *SchemaError by err.
+type schemaIssues struct {
+	registerer SchemaRegisterer
+	strict     bool
+	warning    SchemaWarning
+	warnings   []SchemaWarning
+}
+
+func newSchemaIssues(registerer SchemaRegisterer, r reflect.Value, m reflect.Method, field *ast.Field) *schemaIssues {
+	issues := &schemaIssues{registerer: registerer}
+	if s, ok := registerer.(strictSchemaRegisterer); ok {
+		issues.strict = s.strictSchemas()
+	}
+	issues.warning.Method = m.Name
+	if r.IsValid() {
+		issues.warning.Receiver = r.Type().String()
+	}
+	if field != nil {
+		issues.warning.Param = fieldParamName(field)
+	}
+	return issues
+}
+
+func (s *schemaIssues) warn(ty reflect.Type, message string) {
+	w := s.warning
+	w.Type, w.Message = ty, message
+	s.warnings = append(s.warnings, w)
+	if s.strict {
+		return
+	}
+	if warner, ok := s.registerer.(schemaWarner); ok {
+		warner.warnSchema(w)
+	}
+}
+
+func (s *schemaIssues) err() error {
+	if !s.strict || len(s.warnings) == 0 {
+		return nil
+	}
+	return &SchemaError{s.warnings[0]}
+}
+
+// fieldParamName returns the names of a param or result's field, or its type if it's unnamed.
+func fieldParamName(field *ast.Field) string {
+	if len(field.Names) == 0 {
+		return printIdentField(field)
+	}
+	names := []string{}
+	for _, n := range field.Names {
+		names = append(names, n.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// unsupportedTypeSchema is the fallback schema of types unsupported by JSON Schema, eg. channels and functions.
+var unsupportedTypeSchema = jsonschema.Type{Type: "object", Title: "typeUnsupportedByJSONSchema"}
+
+// unsupportedSchemaTypeMap returns a map documenting types unsupported by JSON Schema, which alecthomas/jsonschema
+// panics on, eg. of struct fields, by the fallback schema, and warning about them.
+func unsupportedSchemaTypeMap(warn func(ty reflect.Type, message string)) SchemaTypeMap {
+	return func(ty reflect.Type) *jsonschema.Type {
+		switch ty.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer, reflect.Uintptr, reflect.Invalid:
+			warn(ty, "type unsupported by JSON Schema, documented as "+unsupportedTypeSchema.Title)
+			schema := unsupportedTypeSchema
+			return &schema
+		}
+		return nil
+	}
+}
@@ -0,0 +1,108 @@
+package go_openrpc_reflect
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type WarningJob struct {
+	Name     string     `json:"name"`
+	Callback func()     `json:"callback"`
+	Weight   complex128 `json:"weight"`
+}
+
+type warningService struct{}
+
+func (s *warningService) Schedule(job WarningJob) (bool, error) {
+	return true, nil
+}
+
+func (s *warningService) Watch(updates chan int) (bool, error) {
+	return true, nil
+}
+
+func TestStandardReflectorT_Strict(t *testing.T) {
+	reflector := &EthereumReflectorT{}
+	reflector.Strict = true
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(reflector)
+	d.RegisterReceiverName("jobs", &warningService{})
+
+	_, err := d.Discover()
+	if !assert.Error(t, err) {
+		t.FailNow()
+	}
+	var schemaErr *SchemaError
+	if !assert.True(t, errors.As(err, &schemaErr), err.Error()) {
+		t.FailNow()
+	}
+	assert.Equal(t, "*go_openrpc_reflect.warningService", schemaErr.Receiver)
+	assert.Equal(t, "Schedule", schemaErr.Method)
+	assert.Equal(t, "job", schemaErr.Param)
+	assert.Equal(t, reflect.TypeOf(func() {}), schemaErr.Type)
+	assert.Empty(t, d.SchemaWarnings())
+}
+
+func TestDocument_SchemaWarnings(t *testing.T) {
+	reflector := &EthereumReflectorT{}
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(reflector)
+	d.RegisterReceiverName("jobs", &warningService{})
+
+	doc, err := d.Discover()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Len(t, *doc.Methods, 2)
+
+	warnings := d.SchemaWarnings()
+	if !assert.Len(t, warnings, 3) {
+		t.FailNow()
+	}
+	assert.Equal(t, "Schedule", warnings[0].Method)
+	assert.Equal(t, reflect.TypeOf(func() {}), warnings[0].Type)
+	assert.Equal(t, reflect.TypeOf(complex128(0)), warnings[1].Type)
+	assert.Equal(t, "Watch", warnings[2].Method)
+	assert.Equal(t, "updates", warnings[2].Param)
+	assert.Contains(t, warnings[2].String(), "*go_openrpc_reflect.warningService.Watch updates: chan int")
+
+	// Warnings are those of each discovery, and of its document only.
+	_, err = d.Discover()
+	assert.NoError(t, err)
+	assert.Len(t, d.SchemaWarnings(), 3)
+
+	other := newDocument().WithMeta(TestMetaRegisterer).WithReflector(reflector)
+	other.RegisterReceiverName("order", &linkOrderService{})
+	_, err = other.Discover()
+	assert.NoError(t, err)
+	assert.Empty(t, other.SchemaWarnings())
+	assert.Len(t, d.SchemaWarnings(), 3)
+	assert.Nil(t, reflector.warnings)
+
+	// Reflectors embedding a default reflector keep their methods, and don't collect warnings.
+	embedding := newDocument().WithMeta(TestMetaRegisterer).WithReflector(&struct{ *EthereumReflectorT }{reflector})
+	embedding.RegisterReceiverName("jobs", &warningService{})
+	doc, err = embedding.Discover()
+	assert.NoError(t, err)
+	assert.Equal(t, "jobs_schedule", string(*(*doc.Methods)[0].Name))
+	assert.Empty(t, embedding.SchemaWarnings())
+}
+
+func TestDocument_SchemaWarnings_Concurrent(t *testing.T) {
+	d := newDocument().WithMeta(TestMetaRegisterer).WithReflector(EthereumReflector)
+	d.RegisterReceiverName("jobs", &warningService{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := d.Discover()
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, d.SchemaWarnings(), 3)
+}